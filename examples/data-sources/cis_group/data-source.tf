@@ -0,0 +1,10 @@
+data "cis_group" "iam_project" {
+  group = "iam-project"
+}
+
+output "iam_project_curators" {
+  value = [
+    for member in data.cis_group.iam_project.members : member.email
+    if member.role == "curator" || member.role == "admin"
+  ]
+}
@@ -0,0 +1,7 @@
+data "cis_org_chart" "example" {
+  email = "jdoe@mozilla.com"
+}
+
+output "approvers" {
+  value = data.cis_org_chart.example.management_chain[*].email
+}
@@ -0,0 +1,11 @@
+data "cis_people_by_timezone" "iam" {
+  team = "Identity and Access Management"
+}
+
+# Split the team into three eight-hour shifts by UTC offset.
+output "iam_on_call_shifts" {
+  value = {
+    for timezone in data.cis_people_by_timezone.iam.timezones :
+    floor((timezone.utc_offset_minutes + 720) / 480) => timezone.members[*].email...
+  }
+}
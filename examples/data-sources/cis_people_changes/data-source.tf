@@ -0,0 +1,17 @@
+variable "last_run" {
+  type    = string
+  default = "2024-05-01T00:00:00Z"
+}
+
+data "cis_people_changes" "recent" {
+  since = var.last_run
+}
+
+output "changed_emails" {
+  value = data.cis_people_changes.recent.people[*].email
+}
+
+# Store this and pass it as last_run on the next scheduled run.
+output "next_run_since" {
+  value = data.cis_people_changes.recent.last_modified
+}
@@ -0,0 +1,10 @@
+data "cis_people_search" "jon" {
+  query = "jon buck"
+  limit = 5
+}
+
+output "jon_matches" {
+  value = {
+    for result in data.cis_people_search.jon.results : result.email => result.match_reason
+  }
+}
@@ -0,0 +1,7 @@
+data "cis_team" "iam" {
+  team = "Identity and Access Management"
+}
+
+output "iam_members" {
+  value = data.cis_team.iam.members[*].email
+}
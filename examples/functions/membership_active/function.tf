@@ -0,0 +1,11 @@
+data "cis_group" "contractors" {
+  group = "temporary-contractors"
+}
+
+# Members whose temporary access has not expired at the time of the plan.
+output "active_contractors" {
+  value = [
+    for member in data.cis_group.contractors.members : member.email
+    if provider::cis::membership_active(member.expires_at, plantimestamp())
+  ]
+}
@@ -7,3 +7,16 @@ terraform {
 }
 
 provider "cis" {}
+
+variable "dev_client_id" {
+  type = string
+}
+
+# A second configuration for comparing against the dev environment,
+# authenticating with a private key instead of a client secret.
+provider "cis" {
+  alias             = "dev"
+  environment       = "dev"
+  auth0_client_id   = var.dev_client_id
+  auth0_private_key = file("cis-dev.pem")
+}
@@ -0,0 +1,4 @@
+resource "cis_person_tags" "example" {
+  user_id = "ad|Mozilla-LDAP|jdoe"
+  tags    = ["service-owner", "on-call"]
+}
@@ -0,0 +1,6 @@
+resource "cis_person_uris" "example" {
+  user_id = "ad|Mozilla-LDAP|jdoe"
+  uris = {
+    "EA#ONCALL#n" = "https://oncall.example.com/schedules/jdoe"
+  }
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addClientError reports an error returned by the Person or Change API
+// client while trying to action. Authentication failures are reported on
+// their own, since the fix is in the provider configuration rather than in
+// the data source or resource.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	var authErr *person_api.AuthError
+	if errors.As(err, &authErr) {
+		diags.AddError(
+			"Authentication Error",
+			fmt.Sprintf("Unable to %s, because the provider could not authenticate to CIS: %s. Check the credentials and endpoints in the provider configuration.", action, authErr.Err.Error()),
+		)
+		return
+	}
+
+	var tooLarge *person_api.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to %s, because the %s API response is larger than %d bytes. Raise max_response_size in the provider configuration to read it.", action, tooLarge.API, tooLarge.Limit),
+		)
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err.Error()))
+}
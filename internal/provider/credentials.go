@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"golang.org/x/oauth2"
+)
+
+// auth0Scopes are the scopes requested for Person and Change API tokens.
+var auth0Scopes = []string{
+	// "classification:public",
+	"classification:workgroup",
+	// "display:none",
+	// "display:public",
+	// "display:authenticated",
+	// "display:vouched",
+	"display:staff",
+}
+
+// newTokenSource returns the token source for the credentials in the
+// provider configuration, in order of preference: a pre-issued access
+// token, a token command, a private key and a client secret. Missing
+// credentials are not an error here. The token source returned fails the
+// first API call instead, so that configurations which never call the API do
+// not need any.
+func newTokenSource(ctx context.Context, data CISProviderModel, getenv func(string) string, endpoints cisEndpoints) (person_api.TokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if accessToken := setting(data.AccessToken.ValueString(), getenv("CIS_ACCESS_TOKEN"), ""); accessToken != "" {
+		return person_api.AccessToken(accessToken), diags
+	}
+
+	if !data.Auth0TokenCommand.IsNull() {
+		var command []string
+		diags.Append(data.Auth0TokenCommand.ElementsAs(ctx, &command, false)...)
+		if len(command) == 0 {
+			diags.AddAttributeError(
+				path.Root("auth0_token_command"),
+				"Empty token command",
+				"auth0_token_command must name at least the program to run.",
+			)
+		}
+
+		return person_api.TokenCommand{Command: command}, diags
+	}
+
+	clientID := setting(data.Auth0ClientID.ValueString(), getenv("AUTH0_CLIENT_ID"), "")
+	if clientID == "" {
+		return unavailableTokenSource{
+			err: errors.New("Auth0 client ID not found in AUTH0_CLIENT_ID environment variable or provider configuration block auth0_client_id attribute"),
+		}, diags
+	}
+
+	if data.Auth0PrivateKey.ValueString() != "" {
+		key, err := person_api.ParsePrivateKey([]byte(data.Auth0PrivateKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("auth0_private_key"),
+				"Invalid Auth0 private key",
+				"auth0_private_key must be a PEM-encoded RSA private key, got error: "+err.Error(),
+			)
+		}
+
+		return cacheTokens(data, getenv, clientID, endpoints, person_api.PrivateKeyJWT{
+			ClientID: clientID,
+			Audience: endpoints.Auth0Audience,
+			TokenURL: endpoints.Auth0Endpoint,
+			Scopes:   auth0Scopes,
+			Key:      key,
+			KeyID:    data.Auth0PrivateKeyID.ValueString(),
+		}), diags
+	}
+
+	clientSecret := setting(data.Auth0ClientSecret.ValueString(), getenv("AUTH0_CLIENT_SECRET"), "")
+	if clientSecret == "" {
+		return unavailableTokenSource{
+			err: errors.New("Auth0 credentials not found. Set access_token, auth0_token_command, auth0_private_key or auth0_client_secret in the provider configuration block, or the AUTH0_CLIENT_SECRET environment variable"),
+		}, diags
+	}
+
+	return cacheTokens(data, getenv, clientID, endpoints, person_api.ClientCredentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Audience:     endpoints.Auth0Audience,
+		TokenURL:     endpoints.Auth0Endpoint,
+		Scopes:       auth0Scopes,
+	}), diags
+}
+
+// cacheTokens wraps the token source of an Auth0 client in a file cache,
+// when token_cache_dir is set.
+func cacheTokens(data CISProviderModel, getenv func(string) string, clientID string, endpoints cisEndpoints, source person_api.TokenSource) person_api.TokenSource {
+	dir := setting(data.TokenCacheDir.ValueString(), getenv("CIS_TOKEN_CACHE_DIR"), "")
+	if dir == "" {
+		return source
+	}
+
+	return person_api.FileTokenCache{
+		Source: source,
+		Dir:    dir,
+		Key:    person_api.TokenCacheKey(clientID, endpoints.Auth0Audience, auth0Scopes),
+	}
+}
+
+// unavailableTokenSource fails every API call with the reason the provider
+// cannot authenticate.
+type unavailableTokenSource struct {
+	err error
+}
+
+func (s unavailableTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	return nil, s.err
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	command, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"token-helper", "--json"})
+
+	tests := []struct {
+		name    string
+		data    CISProviderModel
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "access token from environment",
+			data: CISProviderModel{Auth0ClientSecret: types.StringValue("secret")},
+			env:  map[string]string{"CIS_ACCESS_TOKEN": "token", "AUTH0_CLIENT_ID": "client"},
+			want: "person_api.AccessToken",
+		},
+		{
+			name: "token command",
+			data: CISProviderModel{Auth0TokenCommand: command},
+			want: "person_api.TokenCommand",
+		},
+		{
+			name: "private key",
+			data: CISProviderModel{Auth0ClientID: types.StringValue("client"), Auth0PrivateKey: types.StringValue(keyPEM)},
+			want: "person_api.PrivateKeyJWT",
+		},
+		{
+			name:    "invalid private key",
+			data:    CISProviderModel{Auth0ClientID: types.StringValue("client"), Auth0PrivateKey: types.StringValue("not a key")},
+			wantErr: true,
+		},
+		{
+			name: "client secret",
+			env:  map[string]string{"AUTH0_CLIENT_ID": "client", "AUTH0_CLIENT_SECRET": "secret"},
+			want: "person_api.ClientCredentials",
+		},
+		{
+			name: "cached client secret",
+			data: CISProviderModel{TokenCacheDir: types.StringValue("/tmp/cis")},
+			env:  map[string]string{"AUTH0_CLIENT_ID": "client", "AUTH0_CLIENT_SECRET": "secret"},
+			want: "person_api.FileTokenCache",
+		},
+		{
+			name: "missing credentials",
+			env:  map[string]string{"AUTH0_CLIENT_ID": "client"},
+			want: "provider.unavailableTokenSource",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source, diags := newTokenSource(context.Background(), test.data, func(key string) string { return test.env[key] }, cisEnvironments[EnvironmentProd])
+
+			if diags.HasError() != test.wantErr {
+				t.Fatalf("newTokenSource() diagnostics = %v, want error %t", diags, test.wantErr)
+			}
+			if got := fmt.Sprintf("%T", source); !test.wantErr && got != test.want {
+				t.Errorf("newTokenSource() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
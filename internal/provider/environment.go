@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// cisEndpoints are the endpoints and token audience of one CIS environment.
+type cisEndpoints struct {
+	Auth0Endpoint  string
+	Auth0Audience  string
+	PersonEndpoint string
+	ChangeEndpoint string
+}
+
+// Names of the provider's environment presets.
+const (
+	EnvironmentProd = "prod"
+	EnvironmentDev  = "dev"
+	EnvironmentTest = "test"
+)
+
+// cisEnvironments holds the presets selected with the provider's environment
+// setting.
+var cisEnvironments = map[string]cisEndpoints{
+	EnvironmentProd: {
+		Auth0Endpoint:  "https://auth.mozilla.auth0.com/oauth/token",
+		Auth0Audience:  "api.sso.mozilla.com",
+		PersonEndpoint: "https://person.api.sso.mozilla.com",
+		ChangeEndpoint: "https://change.api.sso.mozilla.com",
+	},
+	EnvironmentDev: {
+		Auth0Endpoint:  "https://auth-dev.mozilla.auth0.com/oauth/token",
+		Auth0Audience:  "api.dev.sso.allizom.org",
+		PersonEndpoint: "https://person.api.dev.sso.allizom.org",
+		ChangeEndpoint: "https://change.api.dev.sso.allizom.org",
+	},
+	EnvironmentTest: {
+		Auth0Endpoint:  "https://auth-dev.mozilla.auth0.com/oauth/token",
+		Auth0Audience:  "api.test.sso.allizom.org",
+		PersonEndpoint: "https://person.api.test.sso.allizom.org",
+		ChangeEndpoint: "https://change.api.test.sso.allizom.org",
+	},
+}
+
+var environmentValidator = stringvalidator.OneOf(EnvironmentProd, EnvironmentDev, EnvironmentTest)
+
+// resolveEndpoints fills in the endpoints of the chosen environment preset
+// that are not overridden, in the configuration or else in the environment
+// variable named by each attribute, and checks that the result is usable.
+func resolveEndpoints(data CISProviderModel, getenv func(string) string) (cisEndpoints, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	environment := setting(data.Environment.ValueString(), getenv("CIS_ENVIRONMENT"), EnvironmentProd)
+	preset, ok := cisEnvironments[environment]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("environment"),
+			"Unknown CIS environment",
+			fmt.Sprintf("CIS_ENVIRONMENT environment variable must be one of %q, %q or %q, got: %q.", EnvironmentProd, EnvironmentDev, EnvironmentTest, environment),
+		)
+		return cisEndpoints{}, diags
+	}
+
+	endpoints := cisEndpoints{
+		Auth0Endpoint:  setting(data.Auth0Endpoint.ValueString(), getenv("AUTH0_ENDPOINT"), preset.Auth0Endpoint),
+		Auth0Audience:  setting(data.Auth0Audience.ValueString(), getenv("AUTH0_AUDIENCE"), preset.Auth0Audience),
+		PersonEndpoint: setting(data.PersonEndpoint.ValueString(), getenv("PERSON_ENDPOINT"), preset.PersonEndpoint),
+		ChangeEndpoint: setting(data.ChangeEndpoint.ValueString(), getenv("CHANGE_ENDPOINT"), preset.ChangeEndpoint),
+	}
+
+	for _, endpoint := range []struct{ name, value string }{
+		{"auth0_endpoint", endpoints.Auth0Endpoint},
+		{"person_endpoint", endpoints.PersonEndpoint},
+		{"change_endpoint", endpoints.ChangeEndpoint},
+	} {
+		if err := validateEndpoint(endpoint.value); err != nil {
+			diags.AddAttributeError(
+				path.Root(endpoint.name),
+				"Invalid CIS endpoint",
+				fmt.Sprintf("%s %q is not usable: %s.", endpoint.name, endpoint.value, err.Error()),
+			)
+		}
+	}
+
+	if err := checkSameEnvironment(endpoints); err != nil {
+		diags.AddError(
+			"Mismatched CIS endpoints",
+			fmt.Sprintf("The configured %s. Set environment, or override every endpoint of the environment.", err.Error()),
+		)
+	}
+
+	return endpoints, diags
+}
+
+// setting returns the first of a configured value, an environment variable
+// and a default that is set.
+func setting(configured string, env string, fallback string) string {
+	if configured != "" {
+		return configured
+	}
+	if env != "" {
+		return env
+	}
+
+	return fallback
+}
+
+// validateEndpoint checks that endpoint is an absolute HTTPS URL without
+// credentials, a query or a fragment.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case u.Scheme != "https":
+		return errors.New("scheme must be https")
+	case u.Host == "":
+		return errors.New("host is missing")
+	case u.User != nil:
+		return errors.New("credentials must not be part of the URL")
+	case u.RawQuery != "" || u.Fragment != "":
+		return errors.New("query and fragment are not allowed")
+	}
+
+	return nil
+}
+
+// checkSameEnvironment returns an error when endpoints include values from
+// presets that have nothing in common, such as a dev audience with the prod
+// Person API. Values that belong to no preset, such as a local Person API,
+// are not checked.
+func checkSameEnvironment(endpoints cisEndpoints) error {
+	var common map[string]bool
+	var seen []string
+
+	for _, value := range []struct {
+		name    string
+		value   string
+		presets func(cisEndpoints) string
+	}{
+		{"auth0_endpoint", endpoints.Auth0Endpoint, func(e cisEndpoints) string { return e.Auth0Endpoint }},
+		{"auth0_audience", endpoints.Auth0Audience, func(e cisEndpoints) string { return e.Auth0Audience }},
+		{"person_endpoint", endpoints.PersonEndpoint, func(e cisEndpoints) string { return e.PersonEndpoint }},
+		{"change_endpoint", endpoints.ChangeEndpoint, func(e cisEndpoints) string { return e.ChangeEndpoint }},
+	} {
+		matching := map[string]bool{}
+		for name, preset := range cisEnvironments {
+			if strings.TrimSuffix(value.presets(preset), "/") == strings.TrimSuffix(value.value, "/") {
+				matching[name] = true
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		seen = append(seen, fmt.Sprintf("%s is from %s", value.name, strings.Join(sortedKeys(matching), " or ")))
+
+		if common == nil {
+			common = matching
+			continue
+		}
+		for name := range common {
+			if !matching[name] {
+				delete(common, name)
+			}
+		}
+		if len(common) == 0 {
+			return fmt.Errorf("endpoints belong to different CIS environments: %s", strings.Join(seen, ", "))
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    CISProviderModel
+		env     map[string]string
+		want    cisEndpoints
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: cisEnvironments[EnvironmentProd],
+		},
+		{
+			name: "environment variable",
+			env:  map[string]string{"CIS_ENVIRONMENT": "dev"},
+			want: cisEnvironments[EnvironmentDev],
+		},
+		{
+			name: "configuration overrides environment variables",
+			data: CISProviderModel{
+				Environment:    types.StringValue("test"),
+				PersonEndpoint: types.StringValue("https://person.example.com"),
+			},
+			env: map[string]string{"CIS_ENVIRONMENT": "dev", "PERSON_ENDPOINT": "https://ignored.example.com"},
+			want: cisEndpoints{
+				Auth0Endpoint:  cisEnvironments[EnvironmentTest].Auth0Endpoint,
+				Auth0Audience:  cisEnvironments[EnvironmentTest].Auth0Audience,
+				PersonEndpoint: "https://person.example.com",
+				ChangeEndpoint: cisEnvironments[EnvironmentTest].ChangeEndpoint,
+			},
+		},
+		{
+			name:    "unknown environment",
+			env:     map[string]string{"CIS_ENVIRONMENT": "staging"},
+			wantErr: true,
+		},
+		{
+			name:    "plain HTTP",
+			data:    CISProviderModel{PersonEndpoint: types.StringValue("http://person.api.sso.mozilla.com")},
+			wantErr: true,
+		},
+		{
+			name:    "query string",
+			data:    CISProviderModel{ChangeEndpoint: types.StringValue("https://change.api.sso.mozilla.com?debug=1")},
+			wantErr: true,
+		},
+		{
+			name:    "mixed environments",
+			data:    CISProviderModel{PersonEndpoint: types.StringValue(cisEnvironments[EnvironmentDev].PersonEndpoint)},
+			wantErr: true,
+		},
+		{
+			name: "environments sharing an Auth0 tenant",
+			data: CISProviderModel{
+				Environment:   types.StringValue("test"),
+				Auth0Endpoint: types.StringValue(cisEnvironments[EnvironmentDev].Auth0Endpoint),
+			},
+			want: cisEnvironments[EnvironmentTest],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, diags := resolveEndpoints(test.data, func(key string) string { return test.env[key] })
+
+			if diags.HasError() != test.wantErr {
+				t.Fatalf("resolveEndpoints() diagnostics = %v, want error %t", diags, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("resolveEndpoints() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GroupDataSource{}
+
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource defines the data source implementation.
+type GroupDataSource struct {
+	providerData *CISProviderData
+}
+
+// GroupDataSourceModel describes the data source data model.
+type GroupDataSourceModel struct {
+	Created_After      types.String `tfsdk:"created_after"`
+	Group              types.String `tfsdk:"group"`
+	Id                 types.String `tfsdk:"id"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	Members            types.List   `tfsdk:"members"`
+	Modified_After     types.String `tfsdk:"modified_after"`
+}
+
+// GroupMemberModel describes a member of a mozilliansorg access group.
+type GroupMemberModel struct {
+	Created       types.String `tfsdk:"created"`
+	Email         types.String `tfsdk:"email"`
+	Expires_At    types.String `tfsdk:"expires_at"`
+	First_Name    types.String `tfsdk:"first_name"`
+	Last_Modified types.String `tfsdk:"last_modified"`
+	Last_Name     types.String `tfsdk:"last_name"`
+	Role          types.String `tfsdk:"role"`
+	User_Id       types.String `tfsdk:"user_id"`
+}
+
+var groupMemberAttrTypes = map[string]attr.Type{
+	"created":       types.StringType,
+	"email":         types.StringType,
+	"expires_at":    types.StringType,
+	"first_name":    types.StringType,
+	"last_modified": types.StringType,
+	"last_name":     types.StringType,
+	"role":          types.StringType,
+	"user_id":       types.StringType,
+}
+
+func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Group data source. Returns the members of a mozilliansorg access group, with their role and membership expiry.",
+
+		Attributes: map[string]schema.Attribute{
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: createdAfterDescription,
+				Optional:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Mozilliansorg access group name",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Group name",
+				Computed:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Members of the group, including those whose membership has expired, ordered by `email`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"created": schema.StringAttribute{
+							MarkdownDescription: createdDescription,
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Primary email address",
+							Computed:            true,
+						},
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: membershipExpiresAtDescription,
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "First name",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: lastModifiedDescription,
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "Last name",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: membershipRoleDescription,
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "People user identifier",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"modified_after": schema.StringAttribute{
+				MarkdownDescription: modifiedAfterDescription,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (d *GroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_group.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data GroupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := data.Group.ValueString()
+	data.Id = types.StringValue(group)
+
+	timeFilter := newTimeFilter(data.Created_After, data.Modified_After, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	people, err := d.providerData.Client.ListGroupMembers(ctx, group, !filter.IncludesInactive(), "created", "first_name", "last_modified", "last_name")
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list group members", err)
+		return
+	}
+
+	members := []GroupMemberModel{}
+	for _, person := range people {
+		if !filter.Apply(person) || !timeFilter.Apply(person) {
+			continue
+		}
+
+		member := GroupMemberModel{
+			Created:       nonSensitiveTimestamp(person.Created.Metadata, person.Created.Value),
+			Email:         nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+			Expires_At:    types.StringNull(),
+			First_Name:    nonSensitiveString(person.FirstName.Metadata, person.FirstName.Value),
+			Last_Modified: nonSensitiveTimestamp(person.LastModified.Metadata, person.LastModified.Value),
+			Last_Name:     nonSensitiveString(person.LastName.Metadata, person.LastName.Value),
+			Role:          types.StringNull(),
+			User_Id:       nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+		}
+
+		// Memberships withheld by the filter leave role and expires_at null.
+		mozilliansorg := person.AccessInformation.Mozilliansorg
+		if value, ok := mozilliansorg.Values[group]; ok {
+			membership := newMembershipModel(person_api.ParseMembership(group, value))
+			member.Role = nonSensitiveString(mozilliansorg.Metadata, membership.Role)
+			if membership.Expires_At != nil {
+				member.Expires_At = nonSensitiveString(mozilliansorg.Metadata, *membership.Expires_At)
+			}
+		}
+
+		members = append(members, member)
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Members, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: groupMemberAttrTypes}, members)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Read group from API", map[string]any{
+		"members": len(members),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = MembershipActiveFunction{}
+)
+
+func NewMembershipActiveFunction() function.Function {
+	return MembershipActiveFunction{}
+}
+
+// MembershipActiveFunction checks a membership expiry against a given time.
+// Provider functions must return the same result for the same arguments, so
+// the time is an argument rather than the current time.
+type MembershipActiveFunction struct{}
+
+func (r MembershipActiveFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "membership_active"
+}
+
+func (r MembershipActiveFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Whether a group membership is active",
+		MarkdownDescription: "Returns whether a mozilliansorg group membership with the given `expires_at` has not expired at time `at`. Pass `plantimestamp()` as `at` to check against the time of the plan.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "expires_at",
+				MarkdownDescription: "The membership's `expires_at`, an RFC 3339 timestamp, or null if it does not expire",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "at",
+				MarkdownDescription: "RFC 3339 timestamp to check the membership at",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (r MembershipActiveFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expiresAt types.String
+	var at string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expiresAt, &at))
+
+	if resp.Error != nil {
+		return
+	}
+
+	atTime, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("at must be an RFC 3339 timestamp, got error: %s", err.Error()))
+		return
+	}
+
+	active := true
+	if !expiresAt.IsNull() {
+		expiresAtTime, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("expires_at must be an RFC 3339 timestamp, got error: %s", err.Error()))
+			return
+		}
+		active = atTime.Before(expiresAtTime)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, active))
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestMembershipActiveFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "before" {
+					value = provider::scaffolding::membership_active("2025-01-01T00:00:00Z", "2024-12-31T23:59:59Z")
+				}
+
+				output "after" {
+					value = provider::scaffolding::membership_active("2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z")
+				}
+
+				output "no_expiry" {
+					value = provider::scaffolding::membership_active(null, "2025-01-01T00:00:00Z")
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("before", "true"),
+					resource.TestCheckOutput("after", "false"),
+					resource.TestCheckOutput("no_expiry", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestMembershipActiveFunction_InvalidTimestamp(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::scaffolding::membership_active("next year", "2025-01-01T00:00:00Z")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`expires_at must be an RFC 3339 timestamp`),
+			},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"terraform-provider-cis/internal/provider/person_api"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// membershipModel describes a mozilliansorg access group membership. It is
+// also encoded to JSON when memberships are moved into sensitive_attributes.
+type membershipModel struct {
+	Expires_At *string `tfsdk:"expires_at" json:"expires_at"`
+	Group      string  `tfsdk:"group" json:"group"`
+	Role       string  `tfsdk:"role" json:"role"`
+}
+
+var membershipAttrTypes = map[string]attr.Type{
+	"expires_at": types.StringType,
+	"group":      types.StringType,
+	"role":       types.StringType,
+}
+
+var membershipRoleDescription = "Role in the group: `" + person_api.RoleMember + "`, `" + person_api.RoleCurator + "` or `" + person_api.RoleAdmin + "`"
+
+var membershipExpiresAtDescription = "When the membership expires, as an RFC 3339 timestamp, or null if it does not. Pass it to `provider::cis::membership_active` to check it."
+
+func newMembershipModel(membership person_api.Membership) membershipModel {
+	model := membershipModel{
+		Group: membership.Group,
+		Role:  membership.Role,
+	}
+
+	if !membership.ExpiresAt.IsZero() {
+		expiresAt := membership.ExpiresAt.UTC().Format(time.RFC3339)
+		model.Expires_At = &expiresAt
+	}
+
+	return model
+}
+
+func newMembershipModels(memberships []person_api.Membership) []membershipModel {
+	models := make([]membershipModel, len(memberships))
+	for i, membership := range memberships {
+		models[i] = newMembershipModel(membership)
+	}
+
+	return models
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrgChartDataSource{}
+
+func NewOrgChartDataSource() datasource.DataSource {
+	return &OrgChartDataSource{}
+}
+
+// OrgChartDataSource defines the data source implementation.
+type OrgChartDataSource struct {
+	providerData *CISProviderData
+}
+
+// OrgChartDataSourceModel describes the data source data model.
+type OrgChartDataSourceModel struct {
+	All_Reports        types.List   `tfsdk:"all_reports"`
+	Created_After      types.String `tfsdk:"created_after"`
+	Direct_Reports     types.List   `tfsdk:"direct_reports"`
+	Email              types.String `tfsdk:"email"`
+	Id                 types.String `tfsdk:"id"`
+	Management_Chain   types.List   `tfsdk:"management_chain"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Depth          types.Int64  `tfsdk:"max_depth"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	Modified_After     types.String `tfsdk:"modified_after"`
+}
+
+// OrgChartPersonModel describes a person in the management chain or reports
+// of an org chart.
+type OrgChartPersonModel struct {
+	Depth         types.Int64  `tfsdk:"depth"`
+	Email         types.String `tfsdk:"email"`
+	First_Name    types.String `tfsdk:"first_name"`
+	Last_Name     types.String `tfsdk:"last_name"`
+	Manager_Email types.String `tfsdk:"manager_email"`
+	Title         types.String `tfsdk:"title"`
+	User_Id       types.String `tfsdk:"user_id"`
+}
+
+var orgChartPersonAttrTypes = map[string]attr.Type{
+	"depth":         types.Int64Type,
+	"email":         types.StringType,
+	"first_name":    types.StringType,
+	"last_name":     types.StringType,
+	"manager_email": types.StringType,
+	"title":         types.StringType,
+	"user_id":       types.StringType,
+}
+
+func (d *OrgChartDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_chart"
+}
+
+func (d *OrgChartDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	orgChartPerson := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"depth": schema.Int64Attribute{
+				MarkdownDescription: "Number of management levels between this person and the person the chart is for",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Primary email address",
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "First name",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "Last name",
+				Computed:            true,
+			},
+			"manager_email": schema.StringAttribute{
+				MarkdownDescription: "Primary email address of this person's manager. Null at the top of the organization, or when the manager or their email is withheld.",
+				Computed:            true,
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Job title",
+				Computed:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "People user identifier",
+				Computed:            true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Org chart data source. Follows the HRIS manager fields in `access_information.hris` up and down from a person. Values classified `INDIVIDUAL CONFIDENTIAL` or `WORKGROUP CONFIDENTIAL: STAFF ONLY` are left null.",
+
+		Attributes: map[string]schema.Attribute{
+			"all_reports": schema.ListNestedAttribute{
+				MarkdownDescription: "Everyone reporting to the person directly or through others, ordered by `depth`, then `email`",
+				Computed:            true,
+				NestedObject:        orgChartPerson,
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: createdAfterDescription + " Applies to `direct_reports` and `all_reports`; the management chain is always returned.",
+				Optional:            true,
+			},
+			"direct_reports": schema.ListNestedAttribute{
+				MarkdownDescription: "People whose manager is the person, ordered by `email`",
+				Computed:            true,
+				NestedObject:        orgChartPerson,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Primary email address of the person to build the org chart for",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "People user identifier of the person",
+				Computed:            true,
+			},
+			"management_chain": schema.ListNestedAttribute{
+				MarkdownDescription: "The person's manager, their manager, and so on up to the top of the organization",
+				Computed:            true,
+				NestedObject:        orgChartPerson,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_depth": schema.Int64Attribute{
+				MarkdownDescription: "Number of management levels below the person to include in `all_reports`. Defaults to no limit.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"modified_after": schema.StringAttribute{
+				MarkdownDescription: modifiedAfterDescription + " Applies to `direct_reports` and `all_reports`; the management chain is always returned.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (d *OrgChartDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *OrgChartDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_org_chart.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data OrgChartDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeFilter := newTimeFilter(data.Created_After, data.Modified_After, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	attributes := []string{"access_information", "created", "first_name", "last_modified", "last_name", "staff_information"}
+
+	person, err := d.providerData.Client.GetPersonByEmail(ctx, data.Email.ValueString(), attributes...)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read person", err)
+		return
+	}
+
+	// Filter the person before walking the chart, so that an omitted or
+	// rejected lookup reads no one else. The walk follows the person's HRIS
+	// links, so it starts from the profile as read rather than as filtered.
+	root := *person
+	chart := &person_api.OrgChart{Person: &person_api.Person{}}
+	if filter.Apply(&root) {
+		chart, err = d.providerData.Client.GetOrgChart(ctx, person, int(data.Max_Depth.ValueInt64()), !filter.IncludesInactive(), attributes...)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read org chart", err)
+			return
+		}
+		chart.Person = &root
+	}
+
+	// Filter everyone before reading manager emails, so that an email the
+	// filter withholds from a manager is not written to state through the
+	// people they manage. Direct reports are also in AllReports, so filter
+	// each person once.
+	kept := map[*person_api.Person]bool{chart.Person: true}
+	for _, manager := range chart.ManagementChain {
+		kept[manager] = filter.Apply(manager)
+	}
+	for _, report := range chart.AllReports {
+		kept[report.Person] = filter.Apply(report.Person)
+	}
+
+	managerEmail := func(manager *person_api.Person) types.String {
+		if manager == nil || !kept[manager] || manager.PrimaryEmail.Value == "" {
+			return types.StringNull()
+		}
+		return nonSensitiveString(manager.PrimaryEmail.Metadata, manager.PrimaryEmail.Value)
+	}
+
+	// Each manager's manager is the next one up the chain.
+	managementChain := []OrgChartPersonModel{}
+	for i, manager := range chart.ManagementChain {
+		if !kept[manager] {
+			continue
+		}
+		var next *person_api.Person
+		if i+1 < len(chart.ManagementChain) {
+			next = chart.ManagementChain[i+1]
+		}
+		managementChain = append(managementChain, newOrgChartPersonModel(manager, i+1, managerEmail(next)))
+	}
+
+	// The time filter only leaves reports out of the lists. The people who
+	// report to them are still included, and still name them as manager.
+	directReports := []OrgChartPersonModel{}
+	for _, report := range chart.DirectReports {
+		if kept[report.Person] && timeFilter.Apply(report.Person) {
+			directReports = append(directReports, newOrgChartPersonModel(report.Person, report.Depth, managerEmail(report.Manager)))
+		}
+	}
+
+	allReports := []OrgChartPersonModel{}
+	for _, report := range chart.AllReports {
+		if kept[report.Person] && timeFilter.Apply(report.Person) {
+			allReports = append(allReports, newOrgChartPersonModel(report.Person, report.Depth, managerEmail(report.Manager)))
+		}
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(chart.Person.UserID.Value)
+
+	var diags diag.Diagnostics
+	data.Management_Chain, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orgChartPersonAttrTypes}, managementChain)
+	resp.Diagnostics.Append(diags...)
+	data.Direct_Reports, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orgChartPersonAttrTypes}, directReports)
+	resp.Diagnostics.Append(diags...)
+	data.All_Reports, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orgChartPersonAttrTypes}, allReports)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Read org chart from API", map[string]any{
+		"management_chain": len(managementChain),
+		"direct_reports":   len(directReports),
+		"all_reports":      len(allReports),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newOrgChartPersonModel(person *person_api.Person, depth int, managerEmail types.String) OrgChartPersonModel {
+	return OrgChartPersonModel{
+		Depth:         types.Int64Value(int64(depth)),
+		Email:         nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+		First_Name:    nonSensitiveString(person.FirstName.Metadata, person.FirstName.Value),
+		Last_Name:     nonSensitiveString(person.LastName.Metadata, person.LastName.Value),
+		Manager_Email: managerEmail,
+		Title:         nonSensitiveString(person.StaffInformation.Title.Metadata, person.StaffInformation.Title.Value),
+		User_Id:       nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+	}
+}
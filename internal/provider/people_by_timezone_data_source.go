@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-cis/internal/provider/person_api"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PeopleByTimezoneDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &PeopleByTimezoneDataSource{}
+
+const (
+	timezoneDescription  = "IANA time zone, such as `Europe/Berlin`. Null when the profile's timezone is not an IANA time zone."
+	utcOffsetDescription = "Offset of `timezone` from UTC at the time of the read, such as `-05:00`"
+)
+
+func NewPeopleByTimezoneDataSource() datasource.DataSource {
+	return &PeopleByTimezoneDataSource{}
+}
+
+// PeopleByTimezoneDataSource defines the data source implementation.
+type PeopleByTimezoneDataSource struct {
+	providerData *CISProviderData
+}
+
+// PeopleByTimezoneDataSourceModel describes the data source data model.
+type PeopleByTimezoneDataSourceModel struct {
+	Cost_Center        types.String `tfsdk:"cost_center"`
+	Group              types.String `tfsdk:"group"`
+	Id                 types.String `tfsdk:"id"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	Team               types.String `tfsdk:"team"`
+	Timezones          types.List   `tfsdk:"timezones"`
+	Unknown            types.List   `tfsdk:"unknown"`
+}
+
+// TimezoneModel describes the people sharing a timezone.
+type TimezoneModel struct {
+	Members            []TimezoneMemberModel `tfsdk:"members"`
+	Timezone           types.String          `tfsdk:"timezone"`
+	Utc_Offset         types.String          `tfsdk:"utc_offset"`
+	Utc_Offset_Minutes types.Int64           `tfsdk:"utc_offset_minutes"`
+}
+
+// TimezoneMemberModel describes a person in a timezone.
+type TimezoneMemberModel struct {
+	Email      types.String `tfsdk:"email"`
+	First_Name types.String `tfsdk:"first_name"`
+	Last_Name  types.String `tfsdk:"last_name"`
+	User_Id    types.String `tfsdk:"user_id"`
+}
+
+var timezoneMemberAttrTypes = map[string]attr.Type{
+	"email":      types.StringType,
+	"first_name": types.StringType,
+	"last_name":  types.StringType,
+	"user_id":    types.StringType,
+}
+
+var timezoneAttrTypes = map[string]attr.Type{
+	"members":            types.ListType{ElemType: types.ObjectType{AttrTypes: timezoneMemberAttrTypes}},
+	"timezone":           types.StringType,
+	"utc_offset":         types.StringType,
+	"utc_offset_minutes": types.Int64Type,
+}
+
+func (d *PeopleByTimezoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_people_by_timezone"
+}
+
+func (d *PeopleByTimezoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	timezoneMember := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Primary email address",
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "First name",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "Last name",
+				Computed:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "People user identifier",
+				Computed:            true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "People by timezone data source. Groups the members of a team, cost center or mozilliansorg access group by the timezone in their profile, for building follow-the-sun rotations.",
+
+		Attributes: map[string]schema.Attribute{
+			"cost_center": schema.StringAttribute{
+				MarkdownDescription: "Cost center whose active staff to group, as found in `staff_information.cost_center`",
+				Optional:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Mozilliansorg access group whose members to group, including those whose membership has expired",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the people grouped, `team:`, `cost_center:` or `group:` followed by its name",
+				Computed:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"team": schema.StringAttribute{
+				MarkdownDescription: "Team whose active staff to group, as found in `staff_information.team`",
+				Optional:            true,
+			},
+			"timezones": schema.ListNestedAttribute{
+				MarkdownDescription: "Timezones of the people, west to east by `utc_offset`, then by `timezone`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"members": schema.ListNestedAttribute{
+							MarkdownDescription: "People in the timezone, ordered by `email`",
+							Computed:            true,
+							NestedObject:        timezoneMember,
+						},
+						"timezone": schema.StringAttribute{
+							MarkdownDescription: "IANA time zone, such as `Europe/Berlin`",
+							Computed:            true,
+						},
+						"utc_offset": schema.StringAttribute{
+							MarkdownDescription: utcOffsetDescription,
+							Computed:            true,
+						},
+						"utc_offset_minutes": schema.Int64Attribute{
+							MarkdownDescription: "`utc_offset` in minutes, negative west of UTC",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"unknown": schema.ListNestedAttribute{
+				MarkdownDescription: "People whose timezone is missing, withheld or not an IANA time zone, ordered by `email`",
+				Computed:            true,
+				NestedObject:        timezoneMember,
+			},
+		},
+	}
+}
+
+func (d *PeopleByTimezoneDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("cost_center"),
+			path.MatchRoot("group"),
+			path.MatchRoot("team"),
+		),
+	}
+}
+
+func (d *PeopleByTimezoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *PeopleByTimezoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_people_by_timezone.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data PeopleByTimezoneDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	var people []*person_api.Person
+	var err error
+
+	attributes := []string{"first_name", "last_name", "timezone"}
+
+	switch {
+	case data.Team.ValueString() != "":
+		data.Id = types.StringValue("team:" + data.Team.ValueString())
+		people, err = d.providerData.Client.ListStaffByTeam(ctx, data.Team.ValueString(), attributes...)
+	case data.Cost_Center.ValueString() != "":
+		data.Id = types.StringValue("cost_center:" + data.Cost_Center.ValueString())
+		people, err = d.providerData.Client.ListStaffByCostCenter(ctx, data.Cost_Center.ValueString(), attributes...)
+	default:
+		data.Id = types.StringValue("group:" + data.Group.ValueString())
+		people, err = d.providerData.Client.ListGroupMembers(ctx, data.Group.ValueString(), !filter.IncludesInactive(), attributes...)
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list people", err)
+		return
+	}
+
+	now := time.Now()
+	timezones := map[string]*TimezoneModel{}
+	offsets := map[string]int{}
+	unknown := []TimezoneMemberModel{}
+	invalid := []string{}
+
+	for _, person := range people {
+		if !filter.Apply(person) {
+			continue
+		}
+
+		member := TimezoneMemberModel{
+			Email:      nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+			First_Name: nonSensitiveString(person.FirstName.Metadata, person.FirstName.Value),
+			Last_Name:  nonSensitiveString(person.LastName.Metadata, person.LastName.Value),
+			User_Id:    nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+		}
+
+		// Withheld timezones are empty, and sensitive ones cannot be shown
+		// in a plain list.
+		if person.Timezone.Value == "" || person.Timezone.Metadata.Classification.Sensitive() {
+			unknown = append(unknown, member)
+			continue
+		}
+
+		location, err := person_api.ParseTimezone(person.Timezone.Value)
+		if err != nil {
+			invalid = append(invalid, person.PrimaryEmail.Value+": "+err.Error())
+			unknown = append(unknown, member)
+			continue
+		}
+
+		name := location.String()
+		if timezones[name] == nil {
+			_, offset := now.In(location).Zone()
+			offsets[name] = offset
+			timezones[name] = &TimezoneModel{
+				Members:            []TimezoneMemberModel{},
+				Timezone:           types.StringValue(name),
+				Utc_Offset:         types.StringValue(person_api.UTCOffset(location, now)),
+				Utc_Offset_Minutes: types.Int64Value(int64(offset / 60)),
+			}
+		}
+		timezones[name].Members = append(timezones[name].Members, member)
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if len(invalid) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Invalid timezone",
+			"The following profiles have a timezone that is not an IANA time zone and are listed in unknown:\n\n"+strings.Join(invalid, "\n"),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := make([]string, 0, len(timezones))
+	for name := range timezones {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if offsets[names[i]] != offsets[names[j]] {
+			return offsets[names[i]] < offsets[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	groups := make([]TimezoneModel, 0, len(names))
+	for _, name := range names {
+		sortTimezoneMembers(timezones[name].Members)
+		groups = append(groups, *timezones[name])
+	}
+	sortTimezoneMembers(unknown)
+
+	var diags diag.Diagnostics
+	data.Timezones, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: timezoneAttrTypes}, groups)
+	resp.Diagnostics.Append(diags...)
+	data.Unknown, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: timezoneMemberAttrTypes}, unknown)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Read people by timezone from API", map[string]any{
+		"timezones": len(groups),
+		"unknown":   len(unknown),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sortTimezoneMembers orders members by email. Withheld emails, which are
+// null, sort first.
+func sortTimezoneMembers(members []TimezoneMemberModel) {
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Email.ValueString() < members[j].Email.ValueString()
+	})
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PeopleChangesDataSource{}
+
+func NewPeopleChangesDataSource() datasource.DataSource {
+	return &PeopleChangesDataSource{}
+}
+
+// PeopleChangesDataSource defines the data source implementation.
+type PeopleChangesDataSource struct {
+	providerData *CISProviderData
+}
+
+// PeopleChangesDataSourceModel describes the data source data model.
+type PeopleChangesDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Last_Modified      types.String `tfsdk:"last_modified"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	People             types.List   `tfsdk:"people"`
+	Since              types.String `tfsdk:"since"`
+}
+
+// PeopleChangesPersonModel describes a changed profile.
+type PeopleChangesPersonModel struct {
+	Active             types.Bool   `tfsdk:"active"`
+	Changed_Attributes types.List   `tfsdk:"changed_attributes"`
+	Email              types.String `tfsdk:"email"`
+	Last_Modified      types.String `tfsdk:"last_modified"`
+	User_Id            types.String `tfsdk:"user_id"`
+}
+
+var peopleChangesPersonAttrTypes = map[string]attr.Type{
+	"active":             types.BoolType,
+	"changed_attributes": types.ListType{ElemType: types.StringType},
+	"email":              types.StringType,
+	"last_modified":      types.StringType,
+	"user_id":            types.StringType,
+}
+
+func (d *PeopleChangesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_people_changes"
+}
+
+func (d *PeopleChangesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "People changes data source. Returns the profiles modified since a given time, so that scheduled runs can process only what changed since the previous one.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The `since` timestamp",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "Latest readable `last_modified` of the returned profiles, or `since` when there are none. Pass it as `since` to the next run to continue where this one stopped.",
+				Computed:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"people": schema.ListNestedAttribute{
+				MarkdownDescription: "Profiles modified after `since`, ordered by `last_modified`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the profile is active, so that deactivations can be told apart from other changes. Null when `active` is too confidential to keep in plain state.",
+							Computed:            true,
+						},
+						"changed_attributes": schema.ListAttribute{
+							MarkdownDescription: "Profile attributes, such as `staff_information.team`, modified after `since`",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Primary email address",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: "When the profile was last modified, as an RFC 3339 timestamp. Null when the profile has no `last_modified` or it is too confidential to keep in plain state.",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "People user identifier",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"since": schema.StringAttribute{
+				MarkdownDescription: "Return profiles modified after this time, as an RFC 3339 timestamp such as `2024-05-01T00:00:00Z`",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (d *PeopleChangesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *PeopleChangesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_people_changes.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data PeopleChangesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since := parseTimestampArgument("since", data.Since, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	people, err := d.providerData.Client.ListPeopleModifiedSince(ctx, since, !filter.IncludesInactive())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list changed people", err)
+		return
+	}
+
+	data.Id = types.StringValue(data.Since.ValueString())
+	data.Last_Modified = types.StringValue(data.Since.ValueString())
+	latest := since
+
+	changes := []PeopleChangesPersonModel{}
+	for _, person := range people {
+		if !filter.Apply(person) {
+			continue
+		}
+
+		changed, diags := types.ListValueFrom(ctx, types.StringType, person.ModifiedSince(since))
+		resp.Diagnostics.Append(diags...)
+
+		lastModified := nonSensitiveTimestamp(person.LastModified.Metadata, person.LastModified.Value)
+
+		changes = append(changes, PeopleChangesPersonModel{
+			Active:             nonSensitiveBool(person.Active.Metadata, person.Active.Value),
+			Changed_Attributes: changed,
+			Email:              nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+			Last_Modified:      lastModified,
+			User_Id:            nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+		})
+
+		if !lastModified.IsNull() && person.LastModified.Value.Time.After(latest) {
+			latest = person.LastModified.Value.Time
+			data.Last_Modified = lastModified
+		}
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.People, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: peopleChangesPersonAttrTypes}, changes)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Read changed people from API", map[string]any{
+		"people": len(changes),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
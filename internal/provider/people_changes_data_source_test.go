@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-cis/internal/provider/person_api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testChangedProfile(userID, email, lastModified, classification string) map[string]any {
+	metadata := map[string]any{"classification": classification, "last_modified": lastModified}
+
+	return map[string]any{
+		"user_id":       map[string]any{"value": userID},
+		"primary_email": map[string]any{"value": email},
+		"active":        map[string]any{"metadata": metadata, "value": true},
+		"last_modified": map[string]any{"metadata": metadata, "value": lastModified},
+	}
+}
+
+func TestPeopleChangesDataSourceConfidential(t *testing.T) {
+	ctx := context.Background()
+	profiles := []map[string]any{
+		testChangedProfile("ad|Mozilla-LDAP|jdoe", "jdoe@mozilla.com", "2025-01-02T00:00:00.000Z", "PUBLIC"),
+		testChangedProfile("ad|Mozilla-LDAP|asmith", "asmith@mozilla.com", "2025-03-04T00:00:00.000Z", "WORKGROUP CONFIDENTIAL: STAFF ONLY"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/users/id/all/by_attribute_contains" {
+			http.NotFound(w, r)
+			return
+		}
+		users := []map[string]any{}
+		for _, profile := range profiles {
+			users = append(users, map[string]any{"id": profile["user_id"], "profile": profile})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"users": users, "nextPage": nil})
+	}))
+	defer server.Close()
+
+	d := &PeopleChangesDataSource{providerData: &CISProviderData{
+		Client:         person_api.NewClient(person_api.AccessToken("test"), server.URL, server.URL, ""),
+		InactivePolicy: InactivePolicyAllow,
+	}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	config.Set(ctx, &PeopleChangesDataSourceModel{
+		Id:                 types.StringNull(),
+		Last_Modified:      types.StringNull(),
+		Max_Classification: types.StringNull(),
+		Max_Display:        types.StringNull(),
+		People:             types.ListNull(types.ObjectType{AttrTypes: peopleChangesPersonAttrTypes}),
+		Since:              types.StringValue("2025-01-01T00:00:00Z"),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var data PeopleChangesDataSourceModel
+	resp.State.Get(ctx, &data)
+	var people []PeopleChangesPersonModel
+	data.People.ElementsAs(ctx, &people, false)
+	if len(people) != 2 {
+		t.Fatalf("Read() returned %d people, want 2", len(people))
+	}
+
+	if people[0].Last_Modified.ValueString() != "2025-01-02T00:00:00Z" || !people[0].Active.ValueBool() {
+		t.Errorf("Read() public profile = %v", people[0])
+	}
+	if !people[1].Last_Modified.IsNull() || !people[1].Active.IsNull() {
+		t.Errorf("Read() kept confidential values in plain state: %v", people[1])
+	}
+
+	// The cursor does not reveal the withheld last_modified either.
+	if data.Last_Modified.ValueString() != "2025-01-02T00:00:00Z" {
+		t.Errorf("Read() last_modified = %s, want 2025-01-02T00:00:00Z", data.Last_Modified)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"terraform-provider-cis/internal/provider/person_api"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -23,16 +26,45 @@ func NewPeopleDataSource() datasource.DataSource {
 
 // PeopleDataSource defines the data source implementation.
 type PeopleDataSource struct {
-	client *person_api.Client
+	providerData *CISProviderData
 }
 
 // PeopleDataSourceModel describes the data source data model.
 type PeopleDataSourceModel struct {
-	Email                types.String `tfsdk:"email"`
-	GitHub_Username      types.String `tfsdk:"github_username"`
-	Id                   types.String `tfsdk:"id"`
-	Mozilliansorg_Groups types.List   `tfsdk:"mozilliansorg_groups"`
-	Username             types.String `tfsdk:"username"`
+	Active                    types.Bool   `tfsdk:"active"`
+	Created                   types.String `tfsdk:"created"`
+	Email                     types.String `tfsdk:"email"`
+	GitHub_Username           types.String `tfsdk:"github_username"`
+	Id                        types.String `tfsdk:"id"`
+	Last_Modified             types.String `tfsdk:"last_modified"`
+	Location                  types.String `tfsdk:"location"`
+	Max_Classification        types.String `tfsdk:"max_classification"`
+	Max_Display               types.String `tfsdk:"max_display"`
+	Mozilliansorg_Groups      types.Set    `tfsdk:"mozilliansorg_groups"`
+	Mozilliansorg_Memberships types.List   `tfsdk:"mozilliansorg_memberships"`
+	Mozilliansorg_Values      types.Map    `tfsdk:"mozilliansorg_group_values"`
+	Office_Location           types.String `tfsdk:"office_location"`
+	Phone_Numbers             types.List   `tfsdk:"phone_numbers"`
+	Sensitive_Attributes      types.Map    `tfsdk:"sensitive_attributes"`
+	Staff                     types.Bool   `tfsdk:"staff"`
+	Timezone                  types.String `tfsdk:"timezone"`
+	Username                  types.String `tfsdk:"username"`
+	Utc_Offset                types.String `tfsdk:"utc_offset"`
+	Worker_Type               types.String `tfsdk:"worker_type"`
+	Wpr_Desk_Number           types.String `tfsdk:"wpr_desk_number"`
+}
+
+// PhoneNumberModel describes one of a person's phone numbers.
+type PhoneNumberModel struct {
+	Label    types.String `tfsdk:"label"`
+	Number   types.String `tfsdk:"number"`
+	Verified types.Bool   `tfsdk:"verified"`
+}
+
+var phoneNumberAttrTypes = map[string]attr.Type{
+	"label":    types.StringType,
+	"number":   types.StringType,
+	"verified": types.BoolType,
 }
 
 func (d *PeopleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,6 +77,14 @@ func (d *PeopleDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "People data source",
 
 		Attributes: map[string]schema.Attribute{
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the profile is active. Inactive profiles are handled according to the provider's `inactive_policy`.",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: createdDescription,
+				Computed:            true,
+			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "People email address",
 				Optional:            true,
@@ -57,15 +97,109 @@ func (d *PeopleDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "People user identifier",
 				Optional:            true,
 			},
-			"mozilliansorg_groups": schema.ListAttribute{
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: lastModifiedDescription,
+				Computed:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Where the person is, as they describe it",
+				Computed:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"mozilliansorg_groups": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Mozilliansorg groups the user is in",
 				Computed:            true,
 			},
+			"mozilliansorg_memberships": schema.ListNestedAttribute{
+				MarkdownDescription: "Mozilliansorg group memberships, ordered by `group`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: membershipExpiresAtDescription,
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Group name",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: membershipRoleDescription,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"mozilliansorg_group_values": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Mozilliansorg groups the user is in, mapped to the membership value CIS stores for each, such as a label or expiry",
+				Computed:            true,
+			},
+			"office_location": schema.StringAttribute{
+				MarkdownDescription: "Office the person works from, from `staff_information.office_location`",
+				Computed:            true,
+			},
+			"phone_numbers": schema.ListNestedAttribute{
+				MarkdownDescription: "Phone numbers in E.164 form, ordered by `label`. Always sensitive, so they are returned here whatever their classification. Numbers without a country code, with an extension or that are otherwise unreadable are left out with a warning.",
+				Computed:            true,
+				Sensitive:           true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							MarkdownDescription: "What the number is for, such as `Mobile` or `Work`",
+							Computed:            true,
+						},
+						"number": schema.StringAttribute{
+							MarkdownDescription: "Number in E.164 form, such as `+14165550100`",
+							Computed:            true,
+						},
+						"verified": schema.BoolAttribute{
+							MarkdownDescription: "Whether CIS marks the phone numbers as verified",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"sensitive_attributes": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Attributes classified `INDIVIDUAL CONFIDENTIAL` or `WORKGROUP CONFIDENTIAL: STAFF ONLY`, keyed by attribute name. Their own attribute is left null. Values that are not strings are JSON-encoded.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"staff": schema.BoolAttribute{
+				MarkdownDescription: "Whether the person is Mozilla staff",
+				Computed:            true,
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: timezoneDescription,
+				Computed:            true,
+			},
 			"username": schema.StringAttribute{
 				MarkdownDescription: "People username",
 				Optional:            true,
 			},
+			"utc_offset": schema.StringAttribute{
+				MarkdownDescription: utcOffsetDescription,
+				Computed:            true,
+			},
+			"worker_type": schema.StringAttribute{
+				MarkdownDescription: "HRIS worker type, such as `Employee` or `Contractor`",
+				Computed:            true,
+			},
+			"wpr_desk_number": schema.StringAttribute{
+				MarkdownDescription: "Desk number, from `staff_information.wpr_desk_number`",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -86,21 +220,24 @@ func (d *PeopleDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*person_api.Client)
+	providerData, ok := req.ProviderData.(*CISProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *person_api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func (d *PeopleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_people.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data PeopleDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -110,39 +247,104 @@ func (d *PeopleDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read people, got error: %s", err))
-	//     return
-	// }
-
-	tflog.Info(ctx, fmt.Sprintf("HTTP Request: %#v", d.client))
-
 	var person *person_api.Person
 	var err error
-	var diags diag.Diagnostics
+
+	attributes := []string{"access_information", "created", "last_modified", "location", "phone_numbers", "primary_username", "staff_information", "timezone", "usernames"}
 
 	if data.Email.ValueString() != "" {
-		person, err = d.client.GetPersonByEmail(ctx, data.Email.ValueString())
+		person, err = d.providerData.Client.GetPersonByEmail(ctx, data.Email.ValueString(), attributes...)
+	} else if data.Id.ValueString() != "" {
+		person, err = d.providerData.Client.GetPersonByUserID(ctx, data.Id.ValueString(), attributes...)
+	} else {
+		person, err = d.providerData.Client.GetPersonByUsername(ctx, data.Username.ValueString(), attributes...)
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read person", err)
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	// An omitted lookup only reports that the profile is inactive.
+	if !filter.Apply(person) {
+		person = &person_api.Person{Active: person.Active}
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sensitive := sensitiveAttributes{}
+
+	// Lookup keys given in the configuration are already visible, so only
+	// values read from the API are routed by classification.
+	if data.Id.ValueString() == "" {
+		data.Id = lookupKey(sensitive, "id", person.UserID.Metadata, person.UserID.Value)
+	}
+	if data.Username.ValueString() == "" {
+		data.Username = lookupKey(sensitive, "username", person.PrimaryUsername.Metadata, person.PrimaryUsername.Value)
+	}
+
+	data.Active = sensitive.Bool("active", person.Active.Metadata, person.Active.Value)
+	data.Created = sensitive.Timestamp("created", person.Created.Metadata, person.Created.Value)
+	data.Last_Modified = sensitive.Timestamp("last_modified", person.LastModified.Metadata, person.LastModified.Value)
+	data.Staff = sensitive.Bool("staff", person.StaffInformation.Staff.Metadata, person.StaffInformation.Staff.Value)
+	data.Worker_Type = sensitive.String("worker_type", person.StaffInformation.WorkerType.Metadata, person.StaffInformation.WorkerType.Value)
+	data.Location = sensitive.String("location", person.Location.Metadata, person.Location.Value)
+	data.Office_Location = sensitive.String("office_location", person.StaffInformation.OfficeLocation.Metadata, person.StaffInformation.OfficeLocation.Value)
+	data.Wpr_Desk_Number = sensitive.String("wpr_desk_number", person.StaffInformation.WprDeskNumber.Metadata, person.StaffInformation.WprDeskNumber.Value)
+
+	phoneNumbers, err := person_api.ParsePhoneNumbers(person.PhoneNumbers.Values)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("phone_numbers"),
+			"Invalid phone number",
+			"The following phone numbers could not be converted to E.164 and were left out:\n\n"+err.Error(),
+		)
+	}
+
+	phoneNumberModels := make([]PhoneNumberModel, len(phoneNumbers))
+	for i, phoneNumber := range phoneNumbers {
+		phoneNumberModels[i] = PhoneNumberModel{
+			Label:    types.StringValue(phoneNumber.Label),
+			Number:   types.StringValue(phoneNumber.Number),
+			Verified: types.BoolValue(person.PhoneNumbers.Metadata.Verified),
+		}
+	}
+
+	data.Timezone, data.Utc_Offset = types.StringNull(), types.StringNull()
+	if timezone := person.Timezone.Value; timezone != "" {
+		location, err := person_api.ParseTimezone(timezone)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read person, got error: %s", err.Error()))
+			resp.Diagnostics.AddAttributeWarning(path.Root("timezone"), "Invalid timezone", fmt.Sprintf("The profile's timezone was left null: %s.", err.Error()))
+		} else {
+			data.Timezone = sensitive.String("timezone", person.Timezone.Metadata, location.String())
+			data.Utc_Offset = sensitive.String("utc_offset", person.Timezone.Metadata, person_api.UTCOffset(location, time.Now()))
 		}
 	}
+	data.GitHub_Username = sensitive.String("github_username", person.Usernames.Metadata, person.Usernames.Values.GitHubUsername)
 
-	tflog.Info(ctx, fmt.Sprintf("Read data from API %#v", person))
+	var diags diag.Diagnostics
+	data.Mozilliansorg_Groups, diags = sensitive.StringSet(ctx, "mozilliansorg_groups", person.AccessInformation.Mozilliansorg.Metadata, person.AccessInformation.Mozilliansorg.List)
+	resp.Diagnostics.Append(diags...)
+	data.Mozilliansorg_Memberships, diags = sensitive.ObjectList(ctx, "mozilliansorg_memberships", person.AccessInformation.Mozilliansorg.Metadata, membershipAttrTypes, newMembershipModels(person.AccessInformation.Mozilliansorg.Memberships()))
+	resp.Diagnostics.Append(diags...)
+	data.Phone_Numbers, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: phoneNumberAttrTypes}, phoneNumberModels)
+	resp.Diagnostics.Append(diags...)
+	data.Mozilliansorg_Values, diags = sensitive.StringMap(ctx, "mozilliansorg_group_values", person.AccessInformation.Mozilliansorg.Metadata, person.AccessInformation.Mozilliansorg.Values)
+	resp.Diagnostics.Append(diags...)
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue(person.UserID.Value)
+	data.Sensitive_Attributes, diags = sensitive.Map(ctx)
+	resp.Diagnostics.Append(diags...)
 
-	data.GitHub_Username = types.StringValue(person.Usernames.Values.GitHubUsername)
-	data.Mozilliansorg_Groups, diags = types.ListValueFrom(ctx, types.StringType, person.AccessInformation.Mozilliansorg.List)
-	for _, d := range diags {
-		resp.Diagnostics.Append(d)
-	}
-	data.Username = types.StringValue(person.PrimaryUsername.Value)
+	// The profile itself is not logged, since it carries confidential
+	// attributes.
+	tflog.Debug(ctx, "Read person from API", map[string]any{
+		"sensitive_attributes": len(sensitive),
+	})
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -155,3 +357,13 @@ func (d *PeopleDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 }
+
+// lookupKey returns a lookup key read from the API, or null when the profile
+// has none, or it was withheld or the profile omitted.
+func lookupKey(sensitive sensitiveAttributes, name string, metadata person_api.Metadata, value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return sensitive.String(name, metadata, value)
+}
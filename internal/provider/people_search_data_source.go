@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSearchLimit is the number of results returned when limit is not set.
+const defaultSearchLimit = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PeopleSearchDataSource{}
+
+func NewPeopleSearchDataSource() datasource.DataSource {
+	return &PeopleSearchDataSource{}
+}
+
+// PeopleSearchDataSource defines the data source implementation.
+type PeopleSearchDataSource struct {
+	providerData *CISProviderData
+}
+
+// PeopleSearchDataSourceModel describes the data source data model.
+type PeopleSearchDataSourceModel struct {
+	Created_After      types.String `tfsdk:"created_after"`
+	Fuzzy              types.Bool   `tfsdk:"fuzzy"`
+	Id                 types.String `tfsdk:"id"`
+	Limit              types.Int64  `tfsdk:"limit"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	Modified_After     types.String `tfsdk:"modified_after"`
+	Query              types.String `tfsdk:"query"`
+	Results            types.List   `tfsdk:"results"`
+}
+
+// PeopleSearchResultModel describes a single search result.
+type PeopleSearchResultModel struct {
+	Alternative_Name types.String  `tfsdk:"alternative_name"`
+	Created          types.String  `tfsdk:"created"`
+	Email            types.String  `tfsdk:"email"`
+	First_Name       types.String  `tfsdk:"first_name"`
+	Last_Modified    types.String  `tfsdk:"last_modified"`
+	Last_Name        types.String  `tfsdk:"last_name"`
+	Match_Reason     types.String  `tfsdk:"match_reason"`
+	Score            types.Float64 `tfsdk:"score"`
+	User_Id          types.String  `tfsdk:"user_id"`
+}
+
+var peopleSearchResultAttrTypes = map[string]attr.Type{
+	"alternative_name": types.StringType,
+	"created":          types.StringType,
+	"email":            types.StringType,
+	"first_name":       types.StringType,
+	"last_modified":    types.StringType,
+	"last_name":        types.StringType,
+	"match_reason":     types.StringType,
+	"score":            types.Float64Type,
+	"user_id":          types.StringType,
+}
+
+func (d *PeopleSearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_people_search"
+}
+
+func (d *PeopleSearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "People search data source. Finds people by first and last name, alternative name or primary email prefix, and with `fuzzy` set, by names with small misspellings.",
+
+		Attributes: map[string]schema.Attribute{
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: createdAfterDescription,
+				Optional:            true,
+			},
+			"fuzzy": schema.BoolAttribute{
+				MarkdownDescription: "Look for similar names among every profile when nothing else matches the query, so that misspelled names are still found. This reads the whole directory, once per provider run. Defaults to `false`.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The search query",
+				Computed:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of results to return. Defaults to `%d`.", defaultSearchLimit),
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"modified_after": schema.StringAttribute{
+				MarkdownDescription: modifiedAfterDescription,
+				Optional:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Name, alternative name or start of a primary email address to search for",
+				Required:            true,
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching people, best match first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"alternative_name": schema.StringAttribute{
+							MarkdownDescription: "Alternative name",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: createdDescription,
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Primary email address",
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "First name",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: lastModifiedDescription,
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "Last name",
+							Computed:            true,
+						},
+						"match_reason": schema.StringAttribute{
+							MarkdownDescription: "What matched the query, such as `email prefix`, `first and last name` or `similar name`",
+							Computed:            true,
+						},
+						"score": schema.Float64Attribute{
+							MarkdownDescription: "How well the person matched, from 0 to 1, where 1 is an exact primary email match",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "People user identifier",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PeopleSearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *PeopleSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_people_search.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data PeopleSearchDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultSearchLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	timeFilter := newTimeFilter(data.Created_After, data.Modified_After, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	// Search without a limit and apply it after filtering, so that profiles
+	// left out by the filters do not take the place of ones that are not.
+	found, err := d.providerData.Client.SearchPeople(ctx, data.Query.ValueString(), person_api.SearchOptions{
+		IncludeInactive: filter.IncludesInactive(),
+		Fuzzy:           data.Fuzzy.ValueBool(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "search people", err)
+		return
+	}
+
+	data.Id = types.StringValue(data.Query.ValueString())
+
+	results := []PeopleSearchResultModel{}
+	for _, result := range found {
+		if int64(len(results)) >= limit {
+			break
+		}
+
+		person := result.Person
+		if !filter.Apply(person) || !timeFilter.Apply(person) {
+			continue
+		}
+
+		results = append(results, PeopleSearchResultModel{
+			Alternative_Name: nonSensitiveString(person.AlternativeName.Metadata, person.AlternativeName.Value),
+			Created:          nonSensitiveTimestamp(person.Created.Metadata, person.Created.Value),
+			Email:            nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+			First_Name:       nonSensitiveString(person.FirstName.Metadata, person.FirstName.Value),
+			Last_Modified:    nonSensitiveTimestamp(person.LastModified.Metadata, person.LastModified.Value),
+			Last_Name:        nonSensitiveString(person.LastName.Metadata, person.LastName.Value),
+			Match_Reason:     types.StringValue(result.MatchReason),
+			Score:            types.Float64Value(result.Score),
+			User_Id:          nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+		})
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Results, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: peopleSearchResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Searched people in API", map[string]any{
+		"results": len(results),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
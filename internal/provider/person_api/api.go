@@ -1,100 +1,318 @@
 package person_api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"golang.org/x/oauth2/clientcredentials"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/oauth2"
 )
 
+// ErrPersonNotFound is returned when the Person API has no profile matching
+// the requested identifier.
+var ErrPersonNotFound = errors.New("person not found")
+
 type Client struct {
-	auth0ClientID     string
-	auth0ClientSecret string
-	auth0Audience     string
-	auth0Endpoint     string
-	auth0Scopes       []string
-	httpClient        *http.Client
-	personEndpoint    string
-
-	auth0AccessToken string
+	cache          *responseCache
+	changeEndpoint string
+	httpClient     *http.Client
+	personEndpoint string
+
+	maxResponseSize int64
+	searchIndex     searchIndex
+	tokenSource     TokenSource
+	userAgent       string
+
+	tokenMu sync.Mutex
+	token   *oauth2.Token
 }
 
-func NewClient(auth0ClientID string, auth0ClientSecret string, auth0Audience string, auth0Endpoint string, auth0Scopes []string, personEndpoint string) *Client {
+// NewClient returns a client for the Person and Change APIs, which sends
+// userAgent with each request. No access token is requested until the first
+// API call.
+func NewClient(tokenSource TokenSource, personEndpoint string, changeEndpoint string, userAgent string) *Client {
 	c := &Client{
-		auth0ClientID:     auth0ClientID,
-		auth0ClientSecret: auth0ClientSecret,
-		auth0Audience:     auth0Audience,
-		auth0Endpoint:     auth0Endpoint,
-		auth0Scopes:       auth0Scopes,
-		httpClient:        &http.Client{},
-		personEndpoint:    personEndpoint,
+		cache:          newResponseCache(defaultCacheTTL),
+		changeEndpoint: changeEndpoint,
+		httpClient:     &http.Client{Transport: newTransport(http.DefaultTransport)},
+		personEndpoint: personEndpoint,
+		tokenSource:    tokenSource,
+		userAgent:      userAgent,
+
+		maxResponseSize: DefaultMaxResponseSize,
 	}
 
 	return c
 }
 
-func (client *Client) GetAccessToken(ctx context.Context) error {
-	oauth2_config := clientcredentials.Config{
-		ClientID:       client.auth0ClientID,
-		ClientSecret:   client.auth0ClientSecret,
-		EndpointParams: url.Values{"audience": {client.auth0Audience}},
-		Scopes:         client.auth0Scopes,
-		TokenURL:       client.auth0Endpoint,
+// GetPersonByEmail reads the profile whose primary email is email. When
+// attributes are given, such as "first_name" or "staff_information", only
+// those top-level attributes of the profile are decoded.
+func (client *Client) GetPersonByEmail(ctx context.Context, email string, attributes ...string) (*Person, error) {
+	return client.getPerson(ctx, "primary_email", email, attributes)
+}
+
+// GetPersonByUserID reads the profile whose user ID is userID, decoding
+// only attributes if given.
+func (client *Client) GetPersonByUserID(ctx context.Context, userID string, attributes ...string) (*Person, error) {
+	return client.getPerson(ctx, "user_id", userID, attributes)
+}
+
+// GetPersonByUsername reads the profile whose primary username is username,
+// decoding only attributes if given.
+func (client *Client) GetPersonByUsername(ctx context.Context, username string, attributes ...string) (*Person, error) {
+	return client.getPerson(ctx, "primary_username", username, attributes)
+}
+
+// getPerson reads the profile whose attribute, one of the lookup keys the
+// Person API offers, is value.
+func (client *Client) getPerson(ctx context.Context, attribute string, value string, attributes []string) (*Person, error) {
+	decode, err := profileDecoder(attributes)
+	if err != nil {
+		return nil, err
 	}
 
-	oauth_token, err := oauth2_config.Token(ctx)
-	tflog.Info(ctx, fmt.Sprintf("HTTP Request: %#v", oauth_token))
+	respBody, err := client.get(ctx, []string{"v2", "user", attribute, value}, nil)
 
-	if err == nil {
-		client.auth0AccessToken = oauth_token.AccessToken
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return nil, ErrPersonNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return err
+	person, err := decode(respBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Person API may answer unknown identifiers with an empty profile
+	// rather than a 404.
+	if person.UserID.Value == "" {
+		return nil, ErrPersonNotFound
+	}
+
+	return person, nil
 }
 
-func (client *Client) GetPersonByEmail(ctx context.Context, email string) (*Person, error) {
+// decodePerson decodes a single profile and fills in the fields derived from
+// it.
+func decodePerson(body []byte) (*Person, error) {
 	person := Person{}
 
-	httpReq, err := http.NewRequest("GET", client.personEndpoint+"/v2/user/primary_email/"+email, nil)
+	err := json.Unmarshal(body, &person)
 	if err != nil {
 		return nil, err
 	}
 
-	httpReq.Header.Add("Authorization", "Bearer "+client.auth0AccessToken)
+	person.SchemaViolations = validateProfile(person.Schema, body)
 
-	httpResp, err := client.httpClient.Do(httpReq)
-	tflog.Info(ctx, fmt.Sprintf("HTTP Request: %#v", httpReq))
+	person.AccessInformation.Mozilliansorg.List = sortedGroups(person.AccessInformation.Mozilliansorg.Values)
+
+	return &person, nil
+}
+
+// sortedGroups converts the keys of mozilliansorg values into a sorted list
+// of strings, so the order does not change from one read to the next.
+func sortedGroups(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// StatusError is returned when the Person or Change API answers with an
+// error status code.
+type StatusError struct {
+	API        string
+	StatusCode int
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("%s API responded with status code %d", err.API, err.StatusCode)
+}
+
+// get reads path, given as unescaped segments, from the Person API. Single
+// responses such as profiles are small, so they are cached for later reads.
+func (client *Client) get(ctx context.Context, path []string, query url.Values) ([]byte, error) {
+	requestURL, err := requestURL(client.personEndpoint, path, query)
 	if err != nil {
 		return nil, err
 	}
 
-	if httpResp.StatusCode >= 400 {
-		return nil, fmt.Errorf("Person API responded with status code %d", httpResp.StatusCode)
+	_, span := tracer().Start(ctx, "person_api.cache")
+	body, ok := client.cache.get(requestURL)
+	span.SetAttributes(attribute.Bool("cis.cache.hit", ok))
+	span.End()
+
+	if ok {
+		if u, err := url.Parse(requestURL); err == nil {
+			tflog.Debug(ctx, "Person API cache hit", map[string]any{"url": redactURL(u)})
+		}
+		return body, nil
+	}
+
+	err = client.read(ctx, path, query, func(r io.Reader) error {
+		var err error
+		body, err = io.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client.cache.put(requestURL, body)
+
+	return body, nil
+}
+
+// read reads path, given as unescaped segments, from the Person API and
+// hands the response body to decode as it arrives. Bodies longer than the
+// maximum response size fail with a ResponseTooLargeError. Nothing read is
+// kept, so that list pages can be streamed through in flat memory.
+func (client *Client) read(ctx context.Context, path []string, query url.Values, decode func(io.Reader) error) error {
+	requestURL, err := requestURL(client.personEndpoint, path, query)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := client.newRequest(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := client.httpClient.Do(httpReq)
+	if err != nil {
+		return err
 	}
 
 	defer httpResp.Body.Close()
 
-	respBody, err := io.ReadAll(httpResp.Body)
+	if httpResp.StatusCode == http.StatusUnauthorized {
+		client.expireToken(ctx)
+	}
+	if httpResp.StatusCode >= 400 {
+		return &StatusError{API: "Person", StatusCode: httpResp.StatusCode}
+	}
+
+	r := newMaxBytesReader(httpResp.Body, "Person", client.maxResponseSize)
+
+	if err := decode(r); err != nil {
+		return err
+	}
+
+	// Whatever decode left unread, such as trailing whitespace, still
+	// counts towards the limit.
+	_, err = io.Copy(io.Discard, r)
+
+	return err
+}
+
+// UpdateOwnedValues reconciles the keys of a key/value attribute (such as
+// "tags" or "uris") that the caller owns. Keys in previous that are missing
+// from desired are removed, keys in desired are set, and every other key is
+// left as another publisher wrote it. The resulting attribute is sent to the
+// Change API.
+func (client *Client) UpdateOwnedValues(ctx context.Context, userID string, attribute string, previous []string, desired StringValues) error {
+	// Merge into the current profile, not one cached earlier in the run.
+	client.cache.clear()
+
+	person, err := client.GetPersonByUserID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	var attr StandardAttributeValues
+	switch attribute {
+	case "tags":
+		attr = person.Tags
+	case "uris":
+		attr = person.Uris
+	default:
+		return fmt.Errorf("attribute %q does not hold key/value pairs", attribute)
+	}
+
+	// Keys owned by others keep their values, null included.
+	values := MergeOwnedValues(attr.Values, previous, desired)
+
+	// The Change API expects null rather than an empty object.
+	if len(values) == 0 {
+		attr.Values = nil
+	} else {
+		attr.Values = values
 	}
 
-	err = json.Unmarshal(respBody, &person)
+	return client.changePerson(ctx, userID, map[string]interface{}{
+		"user_id": person.UserID,
+		attribute: attr,
+	})
+}
+
+// MergeOwnedValues returns current with the keys in previous that are absent
+// from desired removed, and every key in desired set to its desired value.
+func MergeOwnedValues[V any](current map[string]V, previous []string, desired map[string]V) map[string]V {
+	merged := make(map[string]V, len(current)+len(desired))
+	for key, value := range current {
+		merged[key] = value
+	}
+	for _, key := range previous {
+		if _, ok := desired[key]; !ok {
+			delete(merged, key)
+		}
+	}
+	for key, value := range desired {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func (client *Client) changePerson(ctx context.Context, userID string, profile map[string]interface{}) error {
+	body, err := json.Marshal(profile)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Convert map keys into a list of strings
-	keys := make([]string, 0, len(person.AccessInformation.Mozilliansorg.Values))
-	for key := range person.AccessInformation.Mozilliansorg.Values {
-		keys = append(keys, key)
+	requestURL, err := requestURL(client.changeEndpoint, []string{"v2", "user"}, url.Values{"user_id": {userID}})
+	if err != nil {
+		return err
 	}
-	person.AccessInformation.Mozilliansorg.List = keys
 
-	return &person, nil
+	httpReq, err := client.newRequest(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := client.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusUnauthorized {
+		client.expireToken(ctx)
+	}
+	if httpResp.StatusCode >= 400 {
+		return &StatusError{API: "Change", StatusCode: httpResp.StatusCode}
+	}
+
+	// Cached profiles no longer reflect the change.
+	client.cache.clear()
+
+	return nil
 }
@@ -0,0 +1,145 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMergeOwnedValues(t *testing.T) {
+	current := map[string]string{
+		"other":   "kept",
+		"removed": "old",
+		"changed": "old",
+	}
+
+	got := MergeOwnedValues(current, []string{"removed", "changed"}, map[string]string{
+		"changed": "new",
+		"added":   "new",
+	})
+
+	want := map[string]string{
+		"other":   "kept",
+		"changed": "new",
+		"added":   "new",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeOwnedValues() = %v, want %v", got, want)
+	}
+
+	if current["removed"] != "old" {
+		t.Errorf("MergeOwnedValues() modified its input")
+	}
+}
+
+func TestGetPersonByEmailSortsGroups(t *testing.T) {
+	var profile Person
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &profile); err != nil {
+		t.Fatal(err)
+	}
+
+	profile.AccessInformation.Mozilliansorg.Values = map[string]string{}
+	for _, group := range []string{"zeta", "alpha", "mu", "beta", "omega", "kappa"} {
+		profile.AccessInformation.Mozilliansorg.Values[group] = ""
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(profile)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	want := []string{"alpha", "beta", "kappa", "mu", "omega", "zeta"}
+	for i := 0; i < 5; i++ {
+		person, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(person.AccessInformation.Mozilliansorg.List, want) {
+			t.Fatalf("Mozilliansorg.List = %v, want %v", person.AccessInformation.Mozilliansorg.List, want)
+		}
+	}
+}
+
+func TestGetPersonCache(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			requests++
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, server.URL, "")
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests for the same profile, want 1", requests)
+	}
+
+	// A change must not be followed by a stale read.
+	if err := client.UpdateOwnedValues(context.Background(), "ad|Mozilla-LDAP|jdoe", "tags", nil, StringValues{"new": nil}); err != nil {
+		t.Fatal(err)
+	}
+	requests = 0
+	if _, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests after a change, want 1", requests)
+	}
+}
+
+func TestUpdateOwnedValuesKeepsNulls(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posted, _ = io.ReadAll(r.Body)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, server.URL, "")
+	if err := client.UpdateOwnedValues(context.Background(), "ad|Mozilla-LDAP|jdoe", "tags", nil, StringValues{"new": nil}); err != nil {
+		t.Fatal(err)
+	}
+
+	var change struct {
+		Tags StandardAttributeValues `json:"tags"`
+	}
+	if err := json.Unmarshal(posted, &change); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fixture's service-owner tag belongs to another publisher.
+	if want := (StringValues{"service-owner": nil, "new": nil}); !reflect.DeepEqual(change.Tags.Values, want) {
+		t.Errorf("posted tags = %v, want %v", change.Tags.Values, want)
+	}
+}
@@ -0,0 +1,281 @@
+package person_api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// TokenSource supplies the access tokens sent to the Person and Change APIs.
+// Unlike oauth2.TokenSource, it takes the context of the request that needs
+// the token.
+type TokenSource interface {
+	Token(ctx context.Context) (*oauth2.Token, error)
+}
+
+// ClientCredentials fetches access tokens from Auth0 with the OAuth 2.0
+// client credentials grant and a client secret.
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+	Audience     string
+	TokenURL     string
+	Scopes       []string
+}
+
+func (c ClientCredentials) Token(ctx context.Context) (*oauth2.Token, error) {
+	config := clientcredentials.Config{
+		ClientID:       c.ClientID,
+		ClientSecret:   c.ClientSecret,
+		EndpointParams: url.Values{"audience": {c.Audience}},
+		Scopes:         c.Scopes,
+		TokenURL:       c.TokenURL,
+	}
+
+	return config.Token(ctx)
+}
+
+// AuthError is returned by API calls when no access token could be
+// obtained.
+type AuthError struct {
+	Err error
+}
+
+func (err *AuthError) Error() string {
+	return "unable to get an access token: " + err.Err.Error()
+}
+
+func (err *AuthError) Unwrap() error {
+	return err.Err
+}
+
+// accessToken returns the current access token, asking the token source for
+// a new one on first use and once the previous one has expired.
+func (client *Client) accessToken(ctx context.Context) (string, error) {
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	if client.token.Valid() {
+		return client.token.AccessToken, nil
+	}
+
+	ctx, span := tracer().Start(ctx, "person_api.token")
+	token, err := client.tokenSource.Token(ctx)
+	endSpan(span, err)
+	if err != nil {
+		return "", &AuthError{Err: err}
+	}
+
+	// The token itself is a credential and is not logged.
+	tflog.Debug(ctx, "Fetched access token", map[string]any{
+		"expiry": token.Expiry,
+	})
+
+	client.token = token
+
+	return token.AccessToken, nil
+}
+
+// tokenExpirer is a TokenSource that keeps tokens of its own, such as
+// FileTokenCache, and so must be told when the API rejects one.
+type tokenExpirer interface {
+	Expire(ctx context.Context, token *oauth2.Token)
+}
+
+// expireToken drops the current access token, from the token source too if
+// it keeps tokens, so that the next call asks for a new one.
+func (client *Client) expireToken(ctx context.Context) {
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	if expirer, ok := client.tokenSource.(tokenExpirer); ok && client.token != nil {
+		expirer.Expire(ctx, client.token)
+	}
+
+	client.token = nil
+}
+
+// AccessToken is a pre-issued access token, used as is until the API
+// rejects it.
+type AccessToken string
+
+func (token AccessToken) Token(ctx context.Context) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: string(token)}, nil
+}
+
+// privateKeyJWTAssertionType is the client_assertion_type of a JWT client
+// assertion, from RFC 7523.
+const privateKeyJWTAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// privateKeyJWTLifetime is how long a client assertion is valid for. Auth0
+// only needs it for the token request itself.
+const privateKeyJWTLifetime = 5 * time.Minute
+
+// PrivateKeyJWT fetches access tokens from Auth0 with the OAuth 2.0 client
+// credentials grant, authenticating with a JWT signed by the client's
+// private key (Auth0's private_key_jwt) instead of a shared secret.
+type PrivateKeyJWT struct {
+	ClientID string
+	Audience string
+	TokenURL string
+	Scopes   []string
+
+	// Key signs the client assertion with RS256.
+	Key *rsa.PrivateKey
+
+	// KeyID, if set, names the key in the assertion's kid header, for
+	// clients with more than one registered key.
+	KeyID string
+}
+
+func (c PrivateKeyJWT) Token(ctx context.Context) (*oauth2.Token, error) {
+	assertion, err := c.assertion(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	config := clientcredentials.Config{
+		ClientID: c.ClientID,
+		EndpointParams: url.Values{
+			"audience":              {c.Audience},
+			"client_assertion_type": {privateKeyJWTAssertionType},
+			"client_assertion":      {assertion},
+		},
+		Scopes:    c.Scopes,
+		TokenURL:  c.TokenURL,
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+
+	return config.Token(ctx)
+}
+
+// assertion returns a client assertion issued at now.
+func (c PrivateKeyJWT) assertion(now time.Time) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if c.KeyID != "" {
+		header["kid"] = c.KeyID
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	claims := map[string]any{
+		"iss": c.ClientID,
+		"sub": c.ClientID,
+		"aud": c.TokenURL,
+		"iat": now.Unix(),
+		"exp": now.Add(privateKeyJWTLifetime).Unix(),
+		"jti": hex.EncodeToString(jti),
+	}
+
+	encodedHeader, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(encodedHeader) + "." + base64.RawURLEncoding.EncodeToString(encodedClaims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.Key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParsePrivateKey reads an RSA private key from PEM, in either PKCS #1 or
+// PKCS #8 form.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not RSA", key)
+	}
+
+	return rsaKey, nil
+}
+
+// TokenCommand runs an external helper and reads an access token from its
+// output, a JSON object with access_token and, optionally, either
+// expires_in in seconds or expires_at as an RFC 3339 timestamp. Without
+// either, the token is used until the API rejects it.
+type TokenCommand struct {
+	// Command is the program and its arguments. It is run directly, not
+	// through a shell.
+	Command []string
+}
+
+// tokenCommandOutput is what a token command writes to its standard output.
+type tokenCommandOutput struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresIn   int64     `json:"expires_in"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (c TokenCommand) Token(ctx context.Context) (*oauth2.Token, error) {
+	if len(c.Command) == 0 {
+		return nil, errors.New("token command is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("token command %s failed: %w: %s", c.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output tokenCommandOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("token command %s did not write a JSON object: %w", c.Command[0], err)
+	}
+	if output.AccessToken == "" {
+		return nil, fmt.Errorf("token command %s did not return an access_token", c.Command[0])
+	}
+
+	token := &oauth2.Token{AccessToken: output.AccessToken, Expiry: output.ExpiresAt}
+	if output.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(output.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
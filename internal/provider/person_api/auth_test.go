@@ -0,0 +1,232 @@
+package person_api
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// staticToken is a token source for tests that never expires.
+type staticToken string
+
+func (token staticToken) Token(ctx context.Context) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: string(token)}, nil
+}
+
+// countingTokenSource hands out numbered tokens and counts the requests.
+type countingTokenSource struct {
+	calls int
+	err   error
+}
+
+func (source *countingTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	source.calls++
+	if source.err != nil {
+		return nil, source.err
+	}
+	return &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestClientAuthenticatesLazily(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"users": [], "nextPage": null}`))
+	}))
+	defer server.Close()
+
+	source := &countingTokenSource{}
+	client := NewClient(source, server.URL, "", "")
+	if source.calls != 0 {
+		t.Fatalf("NewClient() requested %d tokens, want 0", source.calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListPeopleByAttribute(context.Background(), "staff_information.team", "IAM", true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("two calls requested %d tokens, want 1", source.calls)
+	}
+
+	// A rejected token is replaced on the next call.
+	status = http.StatusUnauthorized
+	client.cache.clear()
+	if _, err := client.ListPeopleByAttribute(context.Background(), "staff_information.team", "IAM", true); err == nil {
+		t.Fatal("ListPeopleByAttribute() succeeded despite 401")
+	}
+	status = http.StatusOK
+	if _, err := client.ListPeopleByAttribute(context.Background(), "staff_information.team", "IAM", true); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 2 {
+		t.Errorf("requested %d tokens after a 401, want 2", source.calls)
+	}
+}
+
+func TestClientAuthError(t *testing.T) {
+	errDenied := errors.New("access denied")
+	client := NewClient(&countingTokenSource{err: errDenied}, "https://person.example.com", "", "")
+
+	_, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || !errors.Is(err, errDenied) {
+		t.Errorf("GetPersonByEmail() error = %v, want AuthError wrapping %v", err, errDenied)
+	}
+}
+
+func TestPrivateKeyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tokenURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("client_secret") != "" || r.Form.Get("client_id") != "client" || r.Form.Get("audience") != "api.sso.mozilla.com" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		if r.Form.Get("client_assertion_type") != privateKeyJWTAssertionType {
+			t.Errorf("client_assertion_type = %q", r.Form.Get("client_assertion_type"))
+		}
+
+		parts := strings.Split(r.Form.Get("client_assertion"), ".")
+		if len(parts) != 3 {
+			t.Fatalf("client_assertion has %d parts, want 3", len(parts))
+		}
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Errorf("client_assertion signature: %s", err)
+		}
+
+		header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+		if !strings.Contains(string(header), `"kid":"key-1"`) {
+			t.Errorf("client_assertion header = %s", header)
+		}
+		claims := map[string]any{}
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			t.Fatal(err)
+		}
+		if claims["iss"] != "client" || claims["sub"] != "client" || claims["aud"] != tokenURL {
+			t.Errorf("client_assertion claims = %v", claims)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "jwt-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+	tokenURL = server.URL + "/oauth/token"
+
+	source := PrivateKeyJWT{
+		ClientID: "client",
+		Audience: "api.sso.mozilla.com",
+		TokenURL: tokenURL,
+		Key:      key,
+		KeyID:    "key-1",
+	}
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "jwt-token" {
+		t.Errorf("Token() = %q, want jwt-token", token.AccessToken)
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, block := range map[string]*pem.Block{
+		"PKCS #1": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"PKCS #8": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		parsed, err := ParsePrivateKey(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Errorf("ParsePrivateKey(%s) error = %s", name, err)
+		} else if !parsed.Equal(key) {
+			t.Errorf("ParsePrivateKey(%s) returned a different key", name)
+		}
+	}
+
+	if _, err := ParsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("ParsePrivateKey() accepted a value without PEM")
+	}
+}
+
+// TestTokenCommandHelper is run by TestTokenCommand as the token command.
+func TestTokenCommandHelper(t *testing.T) {
+	output, ok := os.LookupEnv("TOKEN_COMMAND_OUTPUT")
+	if !ok {
+		t.Skip("only run as a token command")
+	}
+	fmt.Print(output)
+	os.Exit(0)
+}
+
+func TestTokenCommand(t *testing.T) {
+	command := TokenCommand{Command: []string{os.Args[0], "-test.run=^TestTokenCommandHelper$"}}
+
+	tests := []struct {
+		output  string
+		want    string
+		expires bool
+		wantErr bool
+	}{
+		{`{"access_token": "a", "expires_in": 60}`, "a", true, false},
+		{`{"access_token": "b", "expires_at": "2099-01-01T00:00:00Z"}`, "b", true, false},
+		{`{"access_token": "c"}`, "c", false, false},
+		{`{"token": "d"}`, "", false, true},
+		{`token`, "", false, true},
+	}
+
+	for _, test := range tests {
+		t.Setenv("TOKEN_COMMAND_OUTPUT", test.output)
+
+		token, err := command.Token(context.Background())
+		if (err != nil) != test.wantErr {
+			t.Fatalf("Token() with output %s error = %v, want error %t", test.output, err, test.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if token.AccessToken != test.want || token.Expiry.IsZero() == test.expires {
+			t.Errorf("Token() with output %s = %q expiring %s", test.output, token.AccessToken, token.Expiry)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package person_api
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is how long Person API responses are reused. A provider
+// process lives for a single plan or apply, so this mostly saves reading
+// the same profile for several data sources. List pages are not cached.
+const defaultCacheTTL = 5 * time.Minute
+
+// responseCache holds Person API response bodies by request URL.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+func (cache *responseCache) get(key string) ([]byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	return entry.body, true
+}
+
+func (cache *responseCache) put(key string, body []byte) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries[key] = cacheEntry{body: body, expires: time.Now().Add(cache.ttl)}
+}
+
+// clear drops every entry, so that reads after a change see it.
+func (cache *responseCache) clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = map[string]cacheEntry{}
+}
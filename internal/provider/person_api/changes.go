@@ -0,0 +1,96 @@
+package person_api
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ListPeopleModifiedSince returns every profile whose last_modified is after
+// since, ordered by last_modified and then primary email. Profiles with a
+// last_modified that cannot be parsed are returned too, so that callers
+// processing changes do not miss them. When activeOnly is set, inactive
+// profiles are left out by the API.
+//
+// The Person API can only filter on substrings, so profiles are listed by
+// the dates, months and years that make up the time since, and the results
+// are then compared with since exactly.
+func (client *Client) ListPeopleModifiedSince(ctx context.Context, since time.Time, activeOnly bool) ([]*Person, error) {
+	seen := map[string]bool{}
+	people := []*Person{}
+
+	for _, prefix := range modifiedPrefixes(since, time.Now()) {
+		found, err := client.ListPeopleByAttribute(ctx, "last_modified", prefix, activeOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, person := range found {
+			if seen[person.UserID.Value] {
+				continue
+			}
+			seen[person.UserID.Value] = true
+
+			lastModified := person.LastModified.Value.Time
+			if !lastModified.IsZero() && !lastModified.After(since) {
+				continue
+			}
+			people = append(people, person)
+		}
+	}
+
+	sort.SliceStable(people, func(i, j int) bool {
+		// Profiles whose last_modified cannot be parsed come last.
+		a, b := people[i].LastModified.Value.Time, people[j].LastModified.Value.Time
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return people[i].PrimaryEmail.Value < people[j].PrimaryEmail.Value
+	})
+
+	return people, nil
+}
+
+// ModifiedSince returns the JSON paths of the attributes whose metadata
+// last_modified is after since.
+func (person *Person) ModifiedSince(since time.Time) []string {
+	modified := []string{}
+	walkAttributes(reflect.ValueOf(person).Elem(), "", func(name string, _ reflect.Value, metadata reflect.Value) {
+		lastModified, ok := metadata.FieldByName("LastModified").Interface().(Timestamp)
+		if ok && lastModified.Time.After(since) {
+			modified = append(modified, name)
+		}
+	})
+	sort.Strings(modified)
+
+	return modified
+}
+
+// modifiedPrefixes returns the fewest date prefixes, in the form of a CIS
+// timestamp, that together match every time from the start of the UTC day
+// of since to until. Whole years and months are covered by a single prefix.
+func modifiedPrefixes(since time.Time, until time.Time) []string {
+	since, until = since.UTC(), until.UTC()
+
+	prefixes := []string{}
+	cursor := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	for !cursor.After(until) {
+		switch {
+		case cursor.YearDay() == 1:
+			prefixes = append(prefixes, cursor.Format("2006"))
+			cursor = cursor.AddDate(1, 0, 0)
+		case cursor.Day() == 1:
+			prefixes = append(prefixes, cursor.Format("2006-01"))
+			cursor = cursor.AddDate(0, 1, 0)
+		default:
+			prefixes = append(prefixes, cursor.Format("2006-01-02"))
+			cursor = cursor.AddDate(0, 0, 1)
+		}
+	}
+
+	return prefixes
+}
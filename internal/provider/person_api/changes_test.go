@@ -0,0 +1,95 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModifiedPrefixes(t *testing.T) {
+	until := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		since time.Time
+		want  []string
+	}{
+		{time.Date(2025, time.March, 2, 23, 0, 0, 0, time.UTC), []string{"2025-03-02", "2025-03-03"}},
+		{time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC), []string{}},
+		{time.Date(2025, time.February, 27, 0, 0, 0, 0, time.UTC), []string{"2025-02-27", "2025-02-28", "2025-03"}},
+		{time.Date(2023, time.December, 31, 8, 0, 0, 0, time.UTC), []string{"2023-12-31", "2024", "2025"}},
+		// Offsets are converted to UTC before choosing prefixes.
+		{time.Date(2025, time.March, 3, 1, 0, 0, 0, time.FixedZone("CET", 3600)), []string{"2025-03-03"}},
+	}
+
+	for _, test := range tests {
+		if got := modifiedPrefixes(test.since, until); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("modifiedPrefixes(%s) = %v, want %v", test.since, got, test.want)
+		}
+	}
+}
+
+func TestListPeopleModifiedSince(t *testing.T) {
+	now := time.Now().UTC()
+	stamp := func(d time.Duration) string {
+		return now.Add(d).Format("2006-01-02T15:04:05.000Z")
+	}
+	timestamp := func(text string) Timestamp {
+		parsed, _ := ParseTimestamp(text)
+		return Timestamp{Time: parsed, Text: text}
+	}
+	profile := func(id string, email string, lastModified string) Person {
+		person := Person{}
+		person.UserID.Value = id
+		person.PrimaryEmail.Value = email
+		person.LastModified.Value = timestamp(lastModified)
+		person.FirstName.Metadata.LastModified = timestamp(lastModified)
+		person.StaffInformation.Team.Metadata.LastModified = timestamp(stamp(-72 * time.Hour))
+		return person
+	}
+
+	everyone := []Person{
+		profile("ad|1", "old@mozilla.com", stamp(-48*time.Hour)),
+		profile("ad|2", "late@mozilla.com", stamp(-time.Minute)),
+		profile("ad|3", "early@mozilla.com", stamp(-10*time.Minute)),
+		profile("ad|4", "bad@mozilla.com", "yesterday-ish"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := r.URL.Query().Get("last_modified")
+		users := []map[string]interface{}{}
+		for _, person := range everyone {
+			// Stand in for the API's substring match, letting the unparsable
+			// timestamp through as well.
+			if strings.Contains(person.LastModified.Value.Text, prefix) || person.UserID.Value == "ad|4" {
+				users = append(users, map[string]interface{}{"id": person.UserID.Value, "profile": person})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"users": users, "nextPage": nil})
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	since := now.Add(-time.Hour)
+	people, err := client.ListPeopleModifiedSince(context.Background(), since, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emails := []string{}
+	for _, person := range people {
+		emails = append(emails, person.PrimaryEmail.Value)
+	}
+	// Timestamps sort before the unparsable last_modified.
+	if want := []string{"early@mozilla.com", "late@mozilla.com", "bad@mozilla.com"}; !reflect.DeepEqual(emails, want) {
+		t.Errorf("ListPeopleModifiedSince() = %v, want %v", emails, want)
+	}
+
+	if got, want := people[0].ModifiedSince(since), []string{"first_name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ModifiedSince() = %v, want %v", got, want)
+	}
+}
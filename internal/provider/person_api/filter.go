@@ -0,0 +1,125 @@
+package person_api
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Classifications lists the CIS classification levels from least to most
+// restricted.
+var Classifications = []Classification{
+	PUBLIC,
+	MozillaConfidential,
+	WORKGROUPCONFIDENTIAL,
+	WORKGROUPCONFIDENTIALSTAFFONLY,
+	IndividualConfidential,
+}
+
+// DisplayLevels lists the DinoPark display levels from least to most
+// restricted.
+var DisplayLevels = []DinoParkDisplay{
+	Public,
+	Authenticated,
+	Vouched,
+	Ndaed,
+	Staff,
+	Private,
+}
+
+// Sensitive reports whether attributes with this classification must be
+// kept out of logs and plan output.
+func (c Classification) Sensitive() bool {
+	return c == IndividualConfidential || c == WORKGROUPCONFIDENTIALSTAFFONLY
+}
+
+// Withhold blanks out every attribute classified above maxClassification or
+// displayed above maxDisplay, and returns the JSON paths of the attributes it
+// withheld. An empty maximum does not restrict anything. Attributes carrying
+// a classification or display level that is not known are withheld whenever
+// the corresponding maximum is set.
+func (person *Person) Withhold(maxClassification Classification, maxDisplay DinoParkDisplay) []string {
+	withheld := []string{}
+	walkAttributes(reflect.ValueOf(person).Elem(), "", func(name string, attribute reflect.Value, metadata reflect.Value) {
+		classification := Classification(metadata.FieldByName("Classification").String())
+		display := metadataDisplay(metadata.FieldByName("Display"))
+
+		if exceeds(Classifications, classification, maxClassification) || exceeds(DisplayLevels, display, maxDisplay) {
+			for _, valueField := range []string{"Value", "Values", "List"} {
+				if value := attribute.FieldByName(valueField); value.IsValid() {
+					value.SetZero()
+				}
+			}
+			withheld = append(withheld, name)
+		}
+	})
+
+	return withheld
+}
+
+// walkAttributes calls visit with the JSON path, value and metadata of every
+// attribute in v, descending into groups of attributes such as
+// staff_information.
+func walkAttributes(v reflect.Value, prefix string, visit func(name string, attribute reflect.Value, metadata reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+
+		metadata := field.FieldByName("Metadata")
+		if !metadata.IsValid() {
+			walkAttributes(field, prefix+name+".", visit)
+			continue
+		}
+
+		visit(prefix+name, field, metadata)
+	}
+}
+
+// metadataDisplay reads the display level of an attribute. Most attributes
+// use DinoParkDisplay, but access_provider leaves it untyped.
+func metadataDisplay(display reflect.Value) DinoParkDisplay {
+	if display.Kind() == reflect.Interface {
+		if display.IsNil() {
+			return ""
+		}
+		display = display.Elem()
+	}
+	if display.Kind() != reflect.String {
+		return ""
+	}
+
+	return DinoParkDisplay(display.String())
+}
+
+// exceeds reports whether level is more restricted than limit in levels.
+// Unset levels never exceed, and unknown levels exceed any set limit.
+func exceeds[T comparable](levels []T, level T, limit T) bool {
+	var unset T
+	if limit == unset || level == unset {
+		return false
+	}
+
+	return levelIndex(levels, level) > levelIndex(levels, limit)
+}
+
+func levelIndex[T comparable](levels []T, level T) int {
+	for i, l := range levels {
+		if l == level {
+			return i
+		}
+	}
+
+	return len(levels)
+}
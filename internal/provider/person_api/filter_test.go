@@ -0,0 +1,88 @@
+package person_api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPersonWithhold(t *testing.T) {
+	person := Person{
+		FirstName: StandardAttributeString{
+			Metadata: Metadata{Classification: PUBLIC, Display: Public},
+			Value:    "Jane",
+		},
+		PhoneNumbers: StandardAttributeValues{
+			Metadata: Metadata{Classification: IndividualConfidential, Display: Staff},
+			Values:   NewStringValues(map[string]string{"work": "+15555550100"}),
+		},
+		Pronouns: StandardAttributeString{
+			Metadata: Metadata{Classification: PUBLIC, Display: Private},
+			Value:    "she/her",
+		},
+		Identities: IdentitiesAttributesValuesArray{
+			GithubIDV3: &StandardAttributeString{
+				Metadata: Metadata{Classification: WORKGROUPCONFIDENTIAL, Display: Staff},
+				Value:    "1234",
+			},
+		},
+		StaffInformation: StaffInformationValuesArray{
+			CostCenter: StandardAttributeString{
+				Metadata: Metadata{Classification: WORKGROUPCONFIDENTIALSTAFFONLY, Display: Staff},
+				Value:    "1000",
+			},
+		},
+	}
+	person.AccessInformation.Mozilliansorg = MozilliansorgAttribute{
+		Metadata: Metadata{Classification: PUBLIC, Display: Ndaed},
+		Values:   map[string]string{"group": ""},
+		List:     []string{"group"},
+	}
+
+	withheld := person.Withhold(WORKGROUPCONFIDENTIAL, Staff)
+
+	want := []string{"phone_numbers", "pronouns", "staff_information.cost_center"}
+	if !reflect.DeepEqual(withheld, want) {
+		t.Errorf("Withhold() = %v, want %v", withheld, want)
+	}
+
+	if person.FirstName.Value != "Jane" {
+		t.Errorf("first_name was withheld")
+	}
+	if person.PhoneNumbers.Values != nil {
+		t.Errorf("phone_numbers = %v, want nil", person.PhoneNumbers.Values)
+	}
+	if person.Pronouns.Value != "" {
+		t.Errorf("pronouns = %q, want empty", person.Pronouns.Value)
+	}
+	if person.Identities.GithubIDV3.Value != "1234" {
+		t.Errorf("identities.github_id_v3 was withheld")
+	}
+	if person.StaffInformation.CostCenter.Value != "" {
+		t.Errorf("staff_information.cost_center = %q, want empty", person.StaffInformation.CostCenter.Value)
+	}
+	if len(person.AccessInformation.Mozilliansorg.List) != 1 {
+		t.Errorf("access_information.mozilliansorg was withheld")
+	}
+}
+
+func TestPersonWithholdUnlimited(t *testing.T) {
+	person := Person{
+		PhoneNumbers: StandardAttributeValues{
+			Metadata: Metadata{Classification: IndividualConfidential, Display: Private},
+			Values:   NewStringValues(map[string]string{"work": "+15555550100"}),
+		},
+	}
+
+	if withheld := person.Withhold("", ""); len(withheld) != 0 {
+		t.Errorf("Withhold() = %v, want nothing", withheld)
+	}
+}
+
+func TestClassificationSensitive(t *testing.T) {
+	for _, classification := range Classifications {
+		want := classification == IndividualConfidential || classification == WORKGROUPCONFIDENTIALSTAFFONLY
+		if got := classification.Sensitive(); got != want {
+			t.Errorf("%q.Sensitive() = %t, want %t", classification, got, want)
+		}
+	}
+}
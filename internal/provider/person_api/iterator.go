@@ -0,0 +1,136 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"net/url"
+)
+
+// ListOptions selects the profiles People walks through and what it decodes
+// of each.
+type ListOptions struct {
+	// Attribute, when set, restricts the walk to profiles whose attribute,
+	// a dotted path such as "staff_information.team", contains Value, as
+	// with ListPeopleByAttribute.
+	Attribute string
+	Value     string
+
+	// ActiveOnly leaves out inactive profiles.
+	ActiveOnly bool
+
+	// Attributes names the top-level profile attributes to decode, as with
+	// the attributes of GetPersonByEmail. Empty decodes whole profiles.
+	Attributes []string
+}
+
+// profilePage is a page of raw profiles, or the error that ended a walk.
+type profilePage struct {
+	profiles []json.RawMessage
+	err      error
+}
+
+// People returns an iterator over the profiles selected by options, across
+// every page of the Person API's listing. The next page is fetched while the
+// caller works through the current one. The walk ends at the first error,
+// which is yielded with a nil Person, when ctx is cancelled, or when the
+// caller stops ranging.
+func (client *Client) People(ctx context.Context, options ListOptions) iter.Seq2[*Person, error] {
+	return func(yield func(*Person, error) bool) {
+		decode, err := profileDecoder(options.Attributes)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		path := []string{"v2", "users", "id", "all"}
+		query := url.Values{"fullProfiles": {"true"}}
+		if options.Attribute != "" {
+			path = append(path, "by_attribute_contains")
+			query.Set(options.Attribute, options.Value)
+		}
+		if options.ActiveOnly {
+			query.Set("active", "true")
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		pages := make(chan profilePage)
+		done := make(chan struct{})
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		go func() {
+			defer close(done)
+			client.fetchPages(ctx, path, query, pages)
+		}()
+
+		for {
+			var page profilePage
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case p, ok := <-pages:
+				if !ok {
+					return
+				}
+				page = p
+			}
+
+			if page.err != nil {
+				yield(nil, page.err)
+				return
+			}
+
+			for _, profile := range page.profiles {
+				if err := ctx.Err(); err != nil {
+					yield(nil, err)
+					return
+				}
+
+				person, err := decode(profile)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if !yield(person, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// fetchPages sends each page of a list endpoint to pages, one page ahead of
+// the reader, and closes pages after the last one or the first error.
+func (client *Client) fetchPages(ctx context.Context, path []string, query url.Values, pages chan<- profilePage) {
+	defer close(pages)
+
+	for {
+		profiles := []json.RawMessage{}
+		var nextPage json.RawMessage
+		err := client.read(ctx, path, query, func(r io.Reader) error {
+			var err error
+			nextPage, err = decodePage(r, func(profile json.RawMessage) error {
+				profiles = append(profiles, profile)
+				return nil
+			})
+			return err
+		})
+
+		select {
+		case pages <- profilePage{profiles: profiles, err: err}:
+		case <-ctx.Done():
+			return
+		}
+
+		token := pageToken(nextPage)
+		if err != nil || token == "" {
+			return
+		}
+		query.Set("nextPage", token)
+	}
+}
@@ -0,0 +1,169 @@
+package person_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedServer serves pages of two profiles, numbered from 0, linked by
+// nextPage tokens.
+func pagedServer(t *testing.T, pages int, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		page := 0
+		if token := r.URL.Query().Get("nextPage"); token != "" {
+			if _, err := fmt.Sscanf(token, "page-%d", &page); err != nil {
+				t.Errorf("nextPage = %q", token)
+			}
+		}
+
+		nextPage := "null"
+		if page+1 < pages {
+			nextPage = fmt.Sprintf(`"page-%d"`, page+1)
+		}
+		fmt.Fprintf(w, `{"users": [
+			{"id": "%[1]d-a", "profile": {"user_id": {"value": "%[1]d-a"}, "active": {"value": true}, "primary_email": {"value": "%[1]d-a@mozilla.com"}, "first_name": {"value": "A"}}},
+			{"id": "%[1]d-b", "profile": {"user_id": {"value": "%[1]d-b"}, "active": {"value": true}, "primary_email": {"value": "%[1]d-b@mozilla.com"}, "first_name": {"value": "B"}}}
+		], "nextPage": %[2]s}`, page, nextPage)
+	}))
+}
+
+func TestPeople(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedServer(t, 3, &requests)
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	ids := []string{}
+	for person, err := range client.People(context.Background(), ListOptions{}) {
+		if err != nil {
+			t.Fatalf("People() error = %s", err)
+		}
+		ids = append(ids, person.UserID.Value)
+	}
+
+	want := []string{"0-a", "0-b", "1-a", "1-b", "2-a", "2-b"}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("People() = %v, want %v", ids, want)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("made %d requests, want 3", requests.Load())
+	}
+}
+
+func TestPeoplePrefetch(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedServer(t, 5, &requests)
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	for _, err := range client.People(context.Background(), ListOptions{}) {
+		if err != nil {
+			t.Fatalf("People() error = %s", err)
+		}
+
+		// The second page is fetched while the first is being read.
+		deadline := time.Now().Add(5 * time.Second)
+		for requests.Load() < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		break
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("made %d requests after reading one profile, want 2", got)
+	}
+}
+
+func TestPeopleStops(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedServer(t, 3, &requests)
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	read := 0
+	var iterErr error
+	for _, err := range client.People(ctx, ListOptions{}) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		read++
+		cancel()
+	}
+
+	if read != 1 || !errors.Is(iterErr, context.Canceled) {
+		t.Errorf("People() after cancelling read %d profiles and ended with %v, want 1 and context.Canceled", read, iterErr)
+	}
+}
+
+func TestPeopleAttributes(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedServer(t, 1, &requests)
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	for person, err := range client.People(context.Background(), ListOptions{Attributes: []string{"primary_email"}}) {
+		if err != nil {
+			t.Fatalf("People() error = %s", err)
+		}
+		if person.UserID.Value == "" || !person.Active.Value || person.PrimaryEmail.Value == "" {
+			t.Errorf("People() did not decode user_id, active and primary_email: %+v", person)
+		}
+		if person.FirstName.Value != "" {
+			t.Errorf("People() decoded first_name = %q, which was not requested", person.FirstName.Value)
+		}
+		// The served profiles declare no schema, which is reported however
+		// little of them is decoded.
+		if len(person.SchemaViolations) == 0 {
+			t.Error("People() did not check the profile against its schema")
+		}
+	}
+
+	for _, err := range client.People(context.Background(), ListOptions{Attributes: []string{"nickname"}}) {
+		if err == nil {
+			t.Error("People() accepted an unknown attribute")
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("made %d requests, want 1", requests.Load())
+	}
+}
+
+func TestPeopleNotCached(t *testing.T) {
+	var requests atomic.Int32
+	server := pagedServer(t, 3, &requests)
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	// Pages are streamed through rather than kept, so walking the
+	// directory twice reads it twice and leaves the cache empty.
+	for i := 0; i < 2; i++ {
+		for _, err := range client.People(context.Background(), ListOptions{}) {
+			if err != nil {
+				t.Fatalf("People() error = %s", err)
+			}
+		}
+	}
+
+	if requests.Load() != 6 {
+		t.Errorf("made %d requests, want 6", requests.Load())
+	}
+	if entries := len(client.cache.entries); entries != 0 {
+		t.Errorf("cache holds %d entries after listing, want 0", entries)
+	}
+}
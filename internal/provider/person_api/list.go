@@ -0,0 +1,130 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ListPeopleByAttribute returns every profile whose attribute, given as a
+// dotted path such as "staff_information.team", contains value. The Person
+// API matches on substrings, so callers wanting exact matches must compare
+// the returned profiles themselves. When activeOnly is set, inactive
+// profiles are left out by the API. When attributes are given, only those
+// top-level attributes of each profile are decoded.
+func (client *Client) ListPeopleByAttribute(ctx context.Context, attribute string, value string, activeOnly bool, attributes ...string) ([]*Person, error) {
+	people := []*Person{}
+	for person, err := range client.People(ctx, ListOptions{
+		Attribute:  attribute,
+		Value:      value,
+		ActiveOnly: activeOnly,
+		Attributes: attributes,
+	}) {
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, person)
+	}
+
+	return people, nil
+}
+
+// decodePage decodes one page of a list endpoint, of the form
+// {"users": [{"id": ..., "profile": {...}}, ...], "nextPage": ...}, calling
+// visit with each profile as soon as it has been read. It returns the
+// page's nextPage value.
+func decodePage(r io.Reader, visit func(json.RawMessage) error) (json.RawMessage, error) {
+	decoder := json.NewDecoder(r)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+
+	var nextPage json.RawMessage
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "users":
+			if err := decodeUsers(decoder, visit); err != nil {
+				return nil, err
+			}
+		case "nextPage":
+			if err := decoder.Decode(&nextPage); err != nil {
+				return nil, err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return nil, err
+	}
+
+	return nextPage, nil
+}
+
+// decodeUsers decodes the users array of a page, or null.
+func decodeUsers(decoder *json.Decoder, visit func(json.RawMessage) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("expected users to be an array, got %v", token)
+	}
+
+	for decoder.More() {
+		var user struct {
+			Profile json.RawMessage `json:"profile"`
+		}
+		if err := decoder.Decode(&user); err != nil {
+			return err
+		}
+		if err := visit(user.Profile); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token of decoder and fails unless it is delim.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v in list response, got %v", delim, token)
+	}
+
+	return nil
+}
+
+// pageToken turns the nextPage value of a list response into the form the
+// API expects back as a query parameter. The API returns either a string or
+// a JSON object, and null on the last page.
+func pageToken(nextPage json.RawMessage) string {
+	if len(nextPage) == 0 || string(nextPage) == "null" {
+		return ""
+	}
+
+	var token string
+	if err := json.Unmarshal(nextPage, &token); err == nil {
+		return token
+	}
+
+	return string(nextPage)
+}
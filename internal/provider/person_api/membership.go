@@ -0,0 +1,124 @@
+package person_api
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Roles a person can hold in a mozilliansorg access group.
+const (
+	RoleMember  = "member"
+	RoleCurator = "curator"
+	RoleAdmin   = "admin"
+)
+
+// Membership is a person's membership of a mozilliansorg access group.
+type Membership struct {
+	Group string
+	Role  string
+
+	// ExpiresAt is when the membership ends, or the zero time if it does not
+	// expire.
+	ExpiresAt time.Time
+}
+
+// Active reports whether the membership has not expired at the given time.
+func (membership Membership) Active(at time.Time) bool {
+	return membership.ExpiresAt.IsZero() || at.Before(membership.ExpiresAt)
+}
+
+// Memberships returns the person's access group memberships, ordered by
+// group.
+func (attr MozilliansorgAttribute) Memberships() []Membership {
+	memberships := make([]Membership, 0, len(attr.Values))
+	for group, value := range attr.Values {
+		memberships = append(memberships, ParseMembership(group, value))
+	}
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].Group < memberships[j].Group
+	})
+
+	return memberships
+}
+
+// ParseMembership reads the value CIS stores for a group membership. The
+// value is empty for plain members, and otherwise holds words separated by
+// spaces, commas or semicolons: a role, and an expiry given as an RFC 3339
+// timestamp or a date. Either may be written as role=... or expires=...
+// instead, and only an expiry written that way may be given in Unix seconds,
+// so that numbers such as years or ticket numbers are not read as expiries.
+// Words that are neither are ignored.
+func ParseMembership(group string, value string) Membership {
+	membership := Membership{Group: group, Role: RoleMember}
+
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	})
+	for _, word := range words {
+		expiryKey := false
+		if key, rest, ok := strings.Cut(word, "="); ok {
+			switch strings.ToLower(key) {
+			case "role":
+				word = rest
+			case "expires", "expires_at", "expiration":
+				word = rest
+				expiryKey = true
+			}
+		}
+
+		switch role := strings.ToLower(word); role {
+		case RoleMember, RoleCurator, RoleAdmin:
+			membership.Role = role
+			continue
+		}
+
+		if expiresAt, ok := parseExpiry(word, expiryKey); ok {
+			membership.ExpiresAt = expiresAt
+		}
+	}
+
+	return membership
+}
+
+// parseExpiry reads an expiry as a CIS timestamp or a date, or as Unix
+// seconds only when unixSeconds is set.
+func parseExpiry(value string, unixSeconds bool) (time.Time, bool) {
+	if expiresAt, err := ParseTimestamp(value); err == nil {
+		return expiresAt, true
+	}
+	if !unixSeconds {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), true
+	}
+
+	return time.Time{}, false
+}
+
+// ListGroupMembers returns every profile in a mozilliansorg access group,
+// ordered by primary email. When activeOnly is set, inactive profiles are
+// left out by the API. When attributes are given, only those are decoded.
+func (client *Client) ListGroupMembers(ctx context.Context, group string, activeOnly bool, attributes ...string) ([]*Person, error) {
+	people, err := client.ListPeopleByAttribute(ctx, "access_information.mozilliansorg", group, activeOnly, withAttributes(attributes, "access_information")...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API matches group names on substrings.
+	members := []*Person{}
+	for _, person := range people {
+		if _, ok := person.AccessInformation.Mozilliansorg.Values[group]; ok {
+			members = append(members, person)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].PrimaryEmail.Value < members[j].PrimaryEmail.Value
+	})
+
+	return members, nil
+}
@@ -0,0 +1,56 @@
+package person_api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMembership(t *testing.T) {
+	expiry := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  Membership
+	}{
+		{"", Membership{Group: "g", Role: RoleMember}},
+		{"curator", Membership{Group: "g", Role: RoleCurator}},
+		{"2025-01-01T00:00:00.000Z", Membership{Group: "g", Role: RoleMember, ExpiresAt: expiry}},
+		{"Admin; expires=2025-01-01", Membership{Group: "g", Role: RoleAdmin, ExpiresAt: expiry}},
+		{"role=curator,expires_at=1735689600", Membership{Group: "g", Role: RoleCurator, ExpiresAt: expiry}},
+		{"invited by someone", Membership{Group: "g", Role: RoleMember}},
+		{"curator 2024", Membership{Group: "g", Role: RoleCurator}},
+		{"ticket 1735689600", Membership{Group: "g", Role: RoleMember}},
+	}
+
+	for _, test := range tests {
+		if got := ParseMembership("g", test.value); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseMembership(%q) = %+v, want %+v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestMemberships(t *testing.T) {
+	attr := MozilliansorgAttribute{Values: map[string]string{
+		"nda":         "2025-01-01T00:00:00.000Z",
+		"iam-project": "",
+	}}
+
+	memberships := attr.Memberships()
+	if len(memberships) != 2 || memberships[0].Group != "iam-project" || memberships[1].Group != "nda" {
+		t.Fatalf("Memberships() = %+v, want iam-project and nda", memberships)
+	}
+
+	before := time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if !memberships[0].Active(after) {
+		t.Error("membership without expiry is not active")
+	}
+	if !memberships[1].Active(before) {
+		t.Error("membership is not active before it expires")
+	}
+	if memberships[1].Active(after) {
+		t.Error("membership is still active when it expires")
+	}
+}
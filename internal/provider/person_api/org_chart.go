@@ -0,0 +1,148 @@
+package person_api
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// maxManagementChain bounds the walk up the management chain, in case the
+// HRIS data ever contains a loop the visited set does not catch.
+const maxManagementChain = 32
+
+// OrgChart is a person's place in the organization, built from the HRIS
+// manager fields in access_information.hris.
+type OrgChart struct {
+	Person *Person
+
+	// ManagementChain starts with the person's manager and ends with the
+	// top of the organization.
+	ManagementChain []*Person
+
+	// DirectReports and AllReports are sorted by depth, then primary email.
+	DirectReports []OrgChartReport
+	AllReports    []OrgChartReport
+}
+
+// OrgChartReport is a person who reports to the root of an OrgChart,
+// directly or through others.
+type OrgChartReport struct {
+	Person *Person
+
+	// Depth is 1 for direct reports, 2 for their reports, and so on.
+	Depth int
+
+	// Manager is the person's manager: the root of the OrgChart or another
+	// of its reports.
+	Manager *Person
+}
+
+// GetOrgChart walks the HRIS manager fields up from person to the top of the
+// organization, and down through everyone reporting to person. Reports more
+// than maxDepth levels down are not followed; zero means no limit. When
+// attributes are given, only those are decoded of the people in the chart.
+// When activeOnly is set, inactive reports are left out by the API.
+func (client *Client) GetOrgChart(ctx context.Context, person *Person, maxDepth int, activeOnly bool, attributes ...string) (*OrgChart, error) {
+	chart := &OrgChart{Person: person}
+	attributes = withAttributes(attributes, "access_information")
+
+	visited := map[string]bool{workEmail(person): true}
+
+	manager := person
+	for len(chart.ManagementChain) < maxManagementChain {
+		managerEmail := manager.AccessInformation.Hris.Value(HrisManagersPrimaryWorkEmail)
+		if managerEmail == "" || visited[strings.ToLower(managerEmail)] {
+			break
+		}
+		visited[strings.ToLower(managerEmail)] = true
+
+		next, err := client.GetPersonByEmail(ctx, managerEmail, attributes...)
+		if errors.Is(err, ErrPersonNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		chart.ManagementChain = append(chart.ManagementChain, next)
+		manager = next
+	}
+
+	visited = map[string]bool{workEmail(person): true}
+	level := []*Person{person}
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var nextLevel []*Person
+
+		for _, manager := range level {
+			reports, err := client.directReports(ctx, manager, activeOnly, attributes)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, report := range reports {
+				if visited[workEmail(report)] {
+					continue
+				}
+				visited[workEmail(report)] = true
+
+				entry := OrgChartReport{Person: report, Depth: depth, Manager: manager}
+				if depth == 1 {
+					chart.DirectReports = append(chart.DirectReports, entry)
+				}
+				chart.AllReports = append(chart.AllReports, entry)
+				nextLevel = append(nextLevel, report)
+			}
+		}
+
+		level = nextLevel
+	}
+
+	sortReports(chart.DirectReports)
+	sortReports(chart.AllReports)
+
+	return chart, nil
+}
+
+// directReports returns the people whose HRIS manager is manager.
+func (client *Client) directReports(ctx context.Context, manager *Person, activeOnly bool, attributes []string) ([]*Person, error) {
+	email := workEmail(manager)
+	if email == "" {
+		return nil, nil
+	}
+
+	candidates, err := client.ListPeopleByAttribute(ctx, "access_information.hris."+HrisManagersPrimaryWorkEmail, email, activeOnly, attributes...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list endpoint matches substrings, so keep exact matches only.
+	reports := make([]*Person, 0, len(candidates))
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.AccessInformation.Hris.Value(HrisManagersPrimaryWorkEmail), email) {
+			reports = append(reports, candidate)
+		}
+	}
+
+	return reports, nil
+}
+
+// workEmail returns the lower-cased HRIS work email of person, falling back
+// to the primary email.
+func workEmail(person *Person) string {
+	email := person.AccessInformation.Hris.Value(HrisPrimaryWorkEmail)
+	if email == "" {
+		email = person.PrimaryEmail.Value
+	}
+
+	return strings.ToLower(email)
+}
+
+func sortReports(reports []OrgChartReport) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Depth != reports[j].Depth {
+			return reports[i].Depth < reports[j].Depth
+		}
+		return reports[i].Person.PrimaryEmail.Value < reports[j].Person.PrimaryEmail.Value
+	})
+}
@@ -0,0 +1,149 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newOrgChartServer serves a Person API for an organization given as a map
+// of email to manager email. People whose email starts with "former" are
+// inactive.
+func newOrgChartServer(t *testing.T, managers map[string]string) *httptest.Server {
+	profile := func(email string) Person {
+		person := Person{}
+		person.UserID.Value = "ad|Mozilla-LDAP|" + strings.Split(email, "@")[0]
+		person.PrimaryEmail.Value = email
+		person.Active.Value = !strings.HasPrefix(email, "former")
+		person.AccessInformation.Hris.Values = map[string]interface{}{
+			HrisPrimaryWorkEmail:         email,
+			HrisManagersPrimaryWorkEmail: managers[email],
+		}
+		return person
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if email, ok := strings.CutPrefix(r.URL.Path, "/v2/user/primary_email/"); ok {
+			if _, ok := managers[email]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(profile(email))
+			return
+		}
+
+		if r.URL.Path != "/v2/users/id/all/by_attribute_contains" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		manager := r.URL.Query().Get("access_information.hris.managers_primary_work_email")
+		activeOnly := r.URL.Query().Get("active") == "true"
+		page := map[string]interface{}{"nextPage": nil}
+		users := []map[string]interface{}{}
+		for email, managerEmail := range managers {
+			if activeOnly && !profile(email).Active.Value {
+				continue
+			}
+			if strings.Contains(managerEmail, manager) {
+				users = append(users, map[string]interface{}{"id": email, "profile": profile(email)})
+			}
+		}
+		page["users"] = users
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestGetOrgChart(t *testing.T) {
+	server := newOrgChartServer(t, map[string]string{
+		"ceo@mozilla.com":     "",
+		"vp@mozilla.com":      "ceo@mozilla.com",
+		"jdoe@mozilla.com":    "vp@mozilla.com",
+		"bob@mozilla.com":     "jdoe@mozilla.com",
+		"alice@mozilla.com":   "jdoe@mozilla.com",
+		"carol@mozilla.com":   "bob@mozilla.com",
+		"xjdoe@mozilla.com":   "vp@mozilla.com",
+		"xreport@mozilla.com": "xjdoe@mozilla.com",
+	})
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	person, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chart, err := client.GetOrgChart(context.Background(), person, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emails := func(people []*Person) []string {
+		result := []string{}
+		for _, person := range people {
+			result = append(result, person.PrimaryEmail.Value)
+		}
+		return result
+	}
+	reports := func(reports []OrgChartReport) []string {
+		result := []string{}
+		for _, report := range reports {
+			result = append(result, report.Person.PrimaryEmail.Value+" via "+report.Manager.PrimaryEmail.Value)
+		}
+		return result
+	}
+
+	if got, want := emails(chart.ManagementChain), []string{"vp@mozilla.com", "ceo@mozilla.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ManagementChain = %v, want %v", got, want)
+	}
+	if got, want := reports(chart.DirectReports), []string{"alice@mozilla.com via jdoe@mozilla.com", "bob@mozilla.com via jdoe@mozilla.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DirectReports = %v, want %v", got, want)
+	}
+	if got, want := reports(chart.AllReports), []string{"alice@mozilla.com via jdoe@mozilla.com", "bob@mozilla.com via jdoe@mozilla.com", "carol@mozilla.com via bob@mozilla.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllReports = %v, want %v", got, want)
+	}
+
+	chart, err = client.GetOrgChart(context.Background(), person, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart.AllReports) != 2 {
+		t.Errorf("AllReports with max depth 1 = %v, want direct reports only", reports(chart.AllReports))
+	}
+}
+
+func TestGetOrgChartActiveOnly(t *testing.T) {
+	server := newOrgChartServer(t, map[string]string{
+		"jdoe@mozilla.com":   "",
+		"bob@mozilla.com":    "jdoe@mozilla.com",
+		"former@mozilla.com": "jdoe@mozilla.com",
+	})
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	person, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		activeOnly bool
+		want       int
+	}{
+		{false, 2},
+		{true, 1},
+	} {
+		chart, err := client.GetOrgChart(context.Background(), person, 0, test.activeOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chart.DirectReports) != test.want {
+			t.Errorf("GetOrgChart(activeOnly %v) has %d direct reports, want %d", test.activeOnly, len(chart.DirectReports), test.want)
+		}
+	}
+}
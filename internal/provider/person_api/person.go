@@ -1,16 +1,22 @@
 package person_api
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
 type Person struct {
 	AccessInformation AccessInformationValuesArray    `json:"access_information"`
 	Active            StandardAttributeBoolean        `json:"active"`
 	AlternativeName   StandardAttributeString         `json:"alternative_name"`
-	Created           StandardAttributeString         `json:"created"`
+	Created           StandardAttributeTimestamp      `json:"created"`
 	Description       StandardAttributeString         `json:"description"`
 	FirstName         StandardAttributeString         `json:"first_name"`
 	FunTitle          StandardAttributeString         `json:"fun_title"`
 	Identities        IdentitiesAttributesValuesArray `json:"identities"`
 	Languages         StandardAttributeValues         `json:"languages"`
-	LastModified      StandardAttributeString         `json:"last_modified"`
+	LastModified      StandardAttributeTimestamp      `json:"last_modified"`
 	LastName          StandardAttributeString         `json:"last_name"`
 	Location          StandardAttributeString         `json:"location"`
 	LoginMethod       StandardAttributeString         `json:"login_method"`
@@ -29,6 +35,10 @@ type Person struct {
 	UserID            StandardAttributeString         `json:"user_id"`
 	Usernames         UsernamesAttributeValuesObject  `json:"usernames"`
 	UUID              StandardAttributeString         `json:"uuid"`
+
+	// SchemaViolations lists where the profile, as returned by the API,
+	// departs from the vendored schema for the version it declares.
+	SchemaViolations []string `json:"-"`
 }
 
 type AccessInformationValuesArray struct {
@@ -47,8 +57,8 @@ type AccessProviderAttribute struct {
 type AccessProviderMetadata struct {
 	Display        interface{}    `json:"display"`
 	Classification Classification `json:"classification"`
-	Created        string         `json:"created"`
-	LastModified   string         `json:"last_modified"`
+	Created        Timestamp      `json:"created"`
+	LastModified   Timestamp      `json:"last_modified"`
 	Verified       bool           `json:"verified"`
 }
 
@@ -77,6 +87,26 @@ type HrisAttribute struct {
 	Values    map[string]interface{} `json:"values"`
 }
 
+// Keys of access_information.hris values describing where a person sits in
+// the organization.
+const (
+	HrisPrimaryWorkEmail         = "primary_work_email"
+	HrisManagersPrimaryWorkEmail = "managers_primary_work_email"
+)
+
+// Value returns the HRIS value stored under key as a string, or an empty
+// string if it is missing.
+func (attr HrisAttribute) Value(key string) string {
+	switch value := attr.Values[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
 type LDAPAttribute struct {
 	Metadata  Metadata               `json:"metadata"`
 	Signature Signature              `json:"signature"`
@@ -96,6 +126,14 @@ type StandardAttributeString struct {
 	Value     string    `json:"value"`
 }
 
+// StandardAttributeTimestamp is a timestamp attribute, such as created and
+// last_modified.
+type StandardAttributeTimestamp struct {
+	Metadata  Metadata  `json:"metadata"`
+	Signature Signature `json:"signature"`
+	Value     Timestamp `json:"value"`
+}
+
 type StandardAttributeBoolean struct {
 	Metadata  Metadata  `json:"metadata"`
 	Signature Signature `json:"signature"`
@@ -104,9 +142,9 @@ type StandardAttributeBoolean struct {
 
 type Metadata struct {
 	Classification Classification  `json:"classification"`
-	Created        string          `json:"created"`
+	Created        Timestamp       `json:"created"`
 	Display        DinoParkDisplay `json:"display"`
-	LastModified   string          `json:"last_modified"`
+	LastModified   Timestamp       `json:"last_modified"`
 	Verified       bool            `json:"verified"`
 }
 
@@ -142,10 +180,97 @@ type UsernamesAttribute struct {
 	LDAPPOSIXIID   string `json:"LDAP-posix_uid,omitempty"`
 }
 
+// StandardAttributeValues is a key/value attribute, such as languages,
+// tags, URIs, phone numbers and public keys.
 type StandardAttributeValues struct {
-	Metadata  Metadata    `json:"metadata"`
-	Signature Signature   `json:"signature"`
-	Values    interface{} `json:"values"`
+	Metadata  Metadata     `json:"metadata"`
+	Signature Signature    `json:"signature"`
+	Values    StringValues `json:"values"`
+}
+
+// StringMap returns the attribute's values as a map of strings, with keys
+// that have no value mapped to empty strings.
+func (attr StandardAttributeValues) StringMap() map[string]string {
+	return attr.Values.StringMap()
+}
+
+// StringValues holds the values of a key/value attribute. CIS writes a key
+// without a value, as for most languages and tags, as null, which is kept
+// as a nil pointer so that it is not confused with an empty string. A nil
+// StringValues stands for null values, and encodes back to null.
+type StringValues map[string]*string
+
+// NewStringValues returns StringValues holding every key and value of
+// values.
+func NewStringValues(values map[string]string) StringValues {
+	result := make(StringValues, len(values))
+	for key, value := range values {
+		result[key] = &value
+	}
+
+	return result
+}
+
+// StringMap returns values as a map of strings, with keys that have no value
+// mapped to empty strings.
+func (values StringValues) StringMap() map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if value != nil {
+			result[key] = *value
+		} else {
+			result[key] = ""
+		}
+	}
+
+	return result
+}
+
+// Keys returns the keys of values in order.
+func (values StringValues) Keys() []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// UnmarshalJSON reads an object of strings and nulls, or null. Numbers and
+// booleans, which some publishers have written in place of strings, are
+// kept as their JSON text.
+func (values *StringValues) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*values = nil
+		return nil
+	}
+
+	result := make(StringValues, len(raw))
+	for key, value := range raw {
+		switch {
+		case string(value) == "null":
+			result[key] = nil
+		case len(value) > 0 && value[0] == '"':
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return err
+			}
+			result[key] = &s
+		case len(value) > 0 && (value[0] == '{' || value[0] == '['):
+			return fmt.Errorf("value of %q is not a string", key)
+		default:
+			s := string(value)
+			result[key] = &s
+		}
+	}
+	*values = result
+
+	return nil
 }
 
 type StaffInformationValuesArray struct {
@@ -0,0 +1,107 @@
+package person_api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// jsonEqual reports whether a and b hold the same JSON value.
+func jsonEqual(t *testing.T, a []byte, b []byte) bool {
+	t.Helper()
+
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatal(err)
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestStandardAttributeValuesRoundTrip(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var profile map[string]json.RawMessage
+	if err := json.Unmarshal(body, &profile); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"languages", "pgp_public_keys", "phone_numbers", "ssh_public_keys", "tags", "uris"} {
+		var attr StandardAttributeValues
+		if err := json.Unmarshal(profile[name], &attr); err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+
+		encoded, err := json.Marshal(attr)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if !jsonEqual(t, profile[name], encoded) {
+			t.Errorf("%s does not round-trip:\n%s\n%s", name, profile[name], encoded)
+		}
+	}
+}
+
+func TestStringValues(t *testing.T) {
+	work := "+1 (416) 555-0100"
+	empty := ""
+	five := "5"
+
+	tests := []struct {
+		json    string
+		want    StringValues
+		encoded string
+	}{
+		{`null`, nil, `null`},
+		{`{}`, StringValues{}, `{}`},
+		{`{"en": null, "fr": null}`, StringValues{"en": nil, "fr": nil}, `{"en": null, "fr": null}`},
+		{`{"Work": "+1 (416) 555-0100", "Other": ""}`, StringValues{"Work": &work, "Other": &empty}, `{"Work": "+1 (416) 555-0100", "Other": ""}`},
+		{`{"count": 5}`, StringValues{"count": &five}, `{"count": "5"}`},
+	}
+
+	for _, test := range tests {
+		var values StringValues
+		if err := json.Unmarshal([]byte(test.json), &values); err != nil {
+			t.Errorf("Unmarshal(%s) error = %s", test.json, err)
+			continue
+		}
+		if !reflect.DeepEqual(values, test.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", test.json, values, test.want)
+		}
+
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !jsonEqual(t, encoded, []byte(test.encoded)) {
+			t.Errorf("Marshal(%s) = %s, want %s", test.json, encoded, test.encoded)
+		}
+	}
+
+	for _, invalid := range []string{`[]`, `"en"`, `{"en": ["a"]}`, `{"en": {}}`} {
+		var values StringValues
+		if err := json.Unmarshal([]byte(invalid), &values); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", invalid, values)
+		}
+	}
+
+	values := StringValues{"b": nil, "a": &work}
+	if got, want := values.StringMap(), map[string]string{"a": work, "b": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StringMap() = %v, want %v", got, want)
+	}
+	if got, want := values.Keys(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if got := NewStringValues(map[string]string{"a": work}); !reflect.DeepEqual(got, StringValues{"a": &work}) {
+		t.Errorf("NewStringValues() = %v", got)
+	}
+}
@@ -0,0 +1,100 @@
+package person_api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PhoneNumber is one of a person's phone numbers.
+type PhoneNumber struct {
+	// Label is the key CIS stores the number under, such as "Mobile".
+	Label string
+	// Number is the number in E.164 form, such as "+14165550100".
+	Number string
+}
+
+// PhoneNumberError reports a phone number that could not be read. It does
+// not hold the number itself, so that it can be shown without revealing it.
+type PhoneNumberError struct {
+	Label string
+	Err   error
+}
+
+func (err *PhoneNumberError) Error() string {
+	return fmt.Sprintf("%q: %s", err.Label, err.Err)
+}
+
+func (err *PhoneNumberError) Unwrap() error {
+	return err.Err
+}
+
+// ParsePhoneNumbers reads the values of a phone_numbers attribute, ordered
+// by label. Labels without a number are skipped. Numbers that cannot be
+// normalized are left out, and reported together as PhoneNumberErrors.
+func ParsePhoneNumbers(values StringValues) ([]PhoneNumber, error) {
+	numbers := []PhoneNumber{}
+	var errs []error
+	for _, label := range values.Keys() {
+		value := values[label]
+		if value == nil || strings.TrimSpace(*value) == "" {
+			continue
+		}
+
+		number, err := NormalizePhoneNumber(*value)
+		if err != nil {
+			errs = append(errs, &PhoneNumberError{Label: label, Err: err})
+			continue
+		}
+		numbers = append(numbers, PhoneNumber{Label: label, Number: number})
+	}
+
+	return numbers, errors.Join(errs...)
+}
+
+// NormalizePhoneNumber returns an international phone number in E.164 form.
+// Spaces, dots, dashes, slashes and parentheses are ignored, as are a tel:
+// scheme and a "(0)" trunk prefix after the country code, and a leading 00
+// is read as +. Numbers without a country code are rejected rather than
+// guessed at, as are extensions, which E.164 cannot hold.
+func NormalizePhoneNumber(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSpace(strings.TrimPrefix(value, "tel:"))
+	value = strings.ReplaceAll(value, "(0)", "")
+
+	var digits strings.Builder
+	international := false
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && digits.Len() == 0 && !international:
+			international = true
+		case strings.ContainsRune(" .-/()", r):
+		case unicode.IsLetter(r) || r == '#' || r == ',' || r == ';':
+			return "", errors.New("has letters or an extension")
+		default:
+			return "", fmt.Errorf("has an unexpected %q", r)
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	switch {
+	case !international:
+		return "", errors.New("has no country code")
+	case strings.HasPrefix(number, "0"):
+		return "", errors.New("has a country code starting with 0")
+	case len(number) < 7:
+		return "", fmt.Errorf("has %d digits, too few for an international number", len(number))
+	case len(number) > 15:
+		return "", fmt.Errorf("has %d digits, more than the 15 E.164 allows", len(number))
+	}
+
+	return "+" + number, nil
+}
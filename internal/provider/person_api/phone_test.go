@@ -0,0 +1,76 @@
+package person_api
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"+1 (416) 555-0100", "+14165550100"},
+		{"+44 (0)20 7946 0000", "+442079460000"},
+		{"0049 30 1234567", "+49301234567"},
+		{"tel:+33-1-23-45-67-89", "+33123456789"},
+		{" +81.3.1234.5678 ", "+81312345678"},
+	}
+
+	for _, test := range tests {
+		got, err := NormalizePhoneNumber(test.value)
+		if err != nil {
+			t.Errorf("NormalizePhoneNumber(%q) error = %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("NormalizePhoneNumber(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+
+	for _, value := range []string{
+		"416-555-0199",
+		"+1 416 555 0100 x123",
+		"+1 800 FLOWERS",
+		"+0 123 456 789",
+		"+1 234",
+		"+1 2345 6789 0123 4567",
+		"+1 416 555 0100 +1",
+		"n/a",
+	} {
+		if got, err := NormalizePhoneNumber(value); err == nil {
+			t.Errorf("NormalizePhoneNumber(%q) = %q, want error", value, got)
+		}
+	}
+}
+
+func TestParsePhoneNumbers(t *testing.T) {
+	values := NewStringValues(map[string]string{
+		"Work":   "+1 (416) 555-0100",
+		"Mobile": "416-555-0199",
+		"Home":   "+44 20 7946 0000",
+		"Fax":    "",
+	})
+	values["Pager"] = nil
+
+	numbers, err := ParsePhoneNumbers(values)
+
+	want := []PhoneNumber{
+		{Label: "Home", Number: "+442079460000"},
+		{Label: "Work", Number: "+14165550100"},
+	}
+	if !reflect.DeepEqual(numbers, want) {
+		t.Errorf("ParsePhoneNumbers() = %v, want %v", numbers, want)
+	}
+
+	var phoneErr *PhoneNumberError
+	if !errors.As(err, &phoneErr) || phoneErr.Label != "Mobile" {
+		t.Fatalf("ParsePhoneNumbers() error = %v, want a PhoneNumberError for Mobile", err)
+	}
+	// The error names the label but not the number.
+	if strings.Contains(err.Error(), "555") {
+		t.Errorf("ParsePhoneNumbers() error %q reveals the number", err)
+	}
+}
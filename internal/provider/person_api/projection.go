@@ -0,0 +1,97 @@
+package person_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// The Person API always returns whole profiles, signatures and all, and
+// offers no way to select attributes. Callers that only need a few
+// attributes instead ask for just those to be decoded: the rest of each
+// profile is only scanned as raw JSON, which saves most of the decoding
+// work on large lists.
+
+// profileDecoder returns a function decoding raw profiles into People,
+// whole or limited to attributes. The user_id, active and primary_email
+// attributes, which every caller needs to tell profiles apart, are always
+// decoded. Only the decoded attributes are checked against the profile's
+// schema, so that the rest of the profile is never read past its raw JSON.
+func profileDecoder(attributes []string) (func([]byte) (*Person, error), error) {
+	if len(attributes) == 0 {
+		return decodePerson, nil
+	}
+
+	fields := map[string]int{}
+	personType := reflect.TypeOf(Person{})
+	for i := 0; i < personType.NumField(); i++ {
+		name, _, _ := strings.Cut(personType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" && name != "schema" {
+			fields[name] = i
+		}
+	}
+
+	selected := []int{fields["user_id"], fields["active"], fields["primary_email"]}
+	for _, attribute := range attributes {
+		i, ok := fields[attribute]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a profile attribute", attribute)
+		}
+		if !slices.Contains(selected, i) {
+			selected = append(selected, i)
+		}
+	}
+
+	return func(profile []byte) (*Person, error) {
+		return decodeAttributes(profile, selected)
+	}, nil
+}
+
+// decodeAttributes decodes only the given fields of Person from a raw
+// profile, leaving the rest of it, signatures included, undecoded.
+func decodeAttributes(profile []byte, fields []int) (*Person, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(profile, &raw); err != nil {
+		return nil, err
+	}
+
+	person := &Person{}
+	v := reflect.ValueOf(person).Elem()
+	names := make([]string, 0, len(fields))
+	for _, i := range fields {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+		value, ok := raw[name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(value, v.Field(i).Addr().Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	var schema string
+	if value, ok := raw["schema"]; ok {
+		if err := json.Unmarshal(value, &schema); err != nil {
+			return nil, err
+		}
+	}
+	person.SchemaViolations = validateAttributes(schema, raw, names)
+
+	person.AccessInformation.Mozilliansorg.List = sortedGroups(person.AccessInformation.Mozilliansorg.Values)
+
+	return person, nil
+}
+
+// withAttributes adds the attributes a client method needs for itself to the
+// ones its caller asked for. No attributes means whole profiles, which
+// already include them.
+func withAttributes(attributes []string, needed ...string) []string {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	return append(slices.Clone(attributes), needed...)
+}
@@ -0,0 +1,114 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetPersonAttributes(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	whole, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+	if err != nil {
+		t.Fatalf("GetPersonByEmail() error = %s", err)
+	}
+
+	person, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com", "first_name", "access_information")
+	if err != nil {
+		t.Fatalf("GetPersonByEmail() with attributes error = %s", err)
+	}
+
+	// Requested and always decoded attributes match the whole profile.
+	if !reflect.DeepEqual(person.FirstName, whole.FirstName) ||
+		!reflect.DeepEqual(person.AccessInformation, whole.AccessInformation) ||
+		!reflect.DeepEqual(person.UserID, whole.UserID) ||
+		!reflect.DeepEqual(person.Active, whole.Active) ||
+		!reflect.DeepEqual(person.PrimaryEmail, whole.PrimaryEmail) {
+		t.Errorf("GetPersonByEmail() with attributes = %+v, want the attributes of %+v", person, whole)
+	}
+
+	// Everything else is left undecoded.
+	if !reflect.DeepEqual(person.LastName, StandardAttributeString{}) || !reflect.DeepEqual(person.StaffInformation, StaffInformationValuesArray{}) || person.Schema != "" {
+		t.Errorf("GetPersonByEmail() decoded attributes that were not asked for: %+v", person)
+	}
+	if person.SchemaViolations != nil {
+		t.Errorf("GetPersonByEmail() with attributes found schema violations in a valid profile: %v", person.SchemaViolations)
+	}
+
+	if _, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com", "nickname"); err == nil {
+		t.Error("GetPersonByEmail() accepted an unknown attribute")
+	}
+}
+
+func TestWithAttributes(t *testing.T) {
+	if got := withAttributes(nil, "staff_information"); got != nil {
+		t.Errorf("withAttributes(nil) = %v, want whole profiles", got)
+	}
+
+	attributes := []string{"first_name"}
+	got := withAttributes(attributes, "staff_information")
+	if !reflect.DeepEqual(got, []string{"first_name", "staff_information"}) || len(attributes) != 1 {
+		t.Errorf("withAttributes() = %v, and changed its argument to %v", got, attributes)
+	}
+}
+
+func TestGetPersonAttributesSchemaViolations(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The profile has an unknown display level in last_name, and has a signature that cannot
+	// even be decoded in staff_information.
+	var profile map[string]any
+	if err := json.Unmarshal(body, &profile); err != nil {
+		t.Fatal(err)
+	}
+	profile["last_name"].(map[string]any)["metadata"].(map[string]any)["display"] = "everyone"
+	profile["staff_information"].(map[string]any)["title"].(map[string]any)["signature"] = 42
+	body, err = json.Marshal(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	// Attributes that were not asked for are neither decoded nor checked.
+	person, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com", "first_name")
+	if err != nil {
+		t.Fatalf("GetPersonByEmail() error = %s", err)
+	}
+	if person.SchemaViolations != nil {
+		t.Errorf("GetPersonByEmail() schema violations = %v, want none in the attributes asked for", person.SchemaViolations)
+	}
+
+	// Those asked for are.
+	person, err = client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com", "last_name")
+	if err != nil {
+		t.Fatalf("GetPersonByEmail() error = %s", err)
+	}
+	if len(person.SchemaViolations) == 0 || !strings.HasPrefix(person.SchemaViolations[0], "/last_name/metadata/display") {
+		t.Errorf("GetPersonByEmail() schema violations = %v, want one at /last_name/metadata/display", person.SchemaViolations)
+	}
+}
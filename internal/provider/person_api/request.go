@@ -0,0 +1,65 @@
+package person_api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// requestURL joins endpoint with the path segments and adds query. Each
+// segment is escaped, so that identifiers containing "/", "+", "#" or ".."
+// stay within their own segment, and endpoints may end in a slash or not.
+func requestURL(endpoint string, path []string, query url.Values) (string, error) {
+	segments := make([]string, len(path))
+	for i, segment := range path {
+		segments[i] = escapeSegment(segment)
+	}
+
+	joined, err := url.JoinPath(endpoint, segments...)
+	if err != nil {
+		return "", err
+	}
+
+	if len(query) > 0 {
+		joined += "?" + query.Encode()
+	}
+
+	return joined, nil
+}
+
+// escapeSegment escapes a path segment. Unlike url.PathEscape, it also
+// escapes "+", which some servers read as a space, and dot segments, which
+// url.JoinPath would otherwise resolve.
+func escapeSegment(segment string) string {
+	switch segment {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+
+	return strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+}
+
+// newRequest returns an authenticated request to the Person or Change API,
+// bound to ctx so that it is cancelled along with the Terraform operation.
+func (client *Client) newRequest(ctx context.Context, method string, requestURL string, body io.Reader) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := client.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	if client.userAgent != "" {
+		httpReq.Header.Set("User-Agent", client.userAgent)
+	}
+
+	return httpReq, nil
+}
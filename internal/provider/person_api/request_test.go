@@ -0,0 +1,59 @@
+package person_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRequestURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		path     []string
+		query    url.Values
+		want     string
+	}{
+		{"https://person.api.sso.mozilla.com", []string{"v2", "user", "primary_email", "jdoe@mozilla.com"}, nil, "https://person.api.sso.mozilla.com/v2/user/primary_email/jdoe@mozilla.com"},
+		{"https://person.api.sso.mozilla.com/", []string{"v2", "user", "primary_email", "j+doe@mozilla.com"}, nil, "https://person.api.sso.mozilla.com/v2/user/primary_email/j%2Bdoe@mozilla.com"},
+		{"https://person.example.com/prefix/", []string{"v2", "user", "user_id", "ad|Mozilla-LDAP|a/b#c"}, nil, "https://person.example.com/prefix/v2/user/user_id/ad%7CMozilla-LDAP%7Ca%2Fb%23c"},
+		{"https://person.example.com", []string{"v2", "user", "primary_username", ".."}, nil, "https://person.example.com/v2/user/primary_username/%2E%2E"},
+		{"https://change.example.com", []string{"v2", "user"}, url.Values{"user_id": {"ad|a+b"}}, "https://change.example.com/v2/user?user_id=ad%7Ca%2Bb"},
+	}
+
+	for _, test := range tests {
+		got, err := requestURL(test.endpoint, test.path, test.query)
+		if err != nil {
+			t.Errorf("requestURL(%q, %q) error = %s", test.endpoint, test.path, err)
+		} else if got != test.want {
+			t.Errorf("requestURL(%q, %q) = %s, want %s", test.endpoint, test.path, got, test.want)
+		}
+	}
+}
+
+func TestGetPersonRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v2/user/primary_email/j%2Bdoe@mozilla.com" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		if r.Header.Get("User-Agent") != "terraform-provider-cis/test" {
+			t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL+"/", "", "terraform-provider-cis/test")
+	if _, err := client.GetPersonByEmail(context.Background(), "j+doe@mozilla.com"); !errors.Is(err, ErrPersonNotFound) {
+		t.Errorf("GetPersonByEmail() error = %v, want ErrPersonNotFound", err)
+	}
+
+	// Requests are bound to the context of the operation.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetPersonByUsername(ctx, "jdoe"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetPersonByUsername() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
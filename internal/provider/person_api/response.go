@@ -0,0 +1,65 @@
+package person_api
+
+import (
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseSize is the largest response body, in bytes, that the
+// client reads unless told otherwise. Single profiles are a few kilobytes
+// and pages of full profiles a few megabytes.
+const DefaultMaxResponseSize int64 = 16 << 20
+
+// ResponseTooLargeError is returned when a response body is longer than the
+// maximum response size.
+type ResponseTooLargeError struct {
+	API   string
+	Limit int64
+}
+
+func (err *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s API response is larger than %d bytes", err.API, err.Limit)
+}
+
+// SetMaxResponseSize sets the largest response body, in bytes, that the
+// client reads. Longer responses fail with a ResponseTooLargeError.
+func (client *Client) SetMaxResponseSize(size int64) {
+	client.maxResponseSize = size
+}
+
+// maxBytesReader reads at most limit bytes from r, and fails with a
+// ResponseTooLargeError once r has more.
+type maxBytesReader struct {
+	r         io.Reader
+	remaining int64
+	err       *ResponseTooLargeError
+}
+
+func newMaxBytesReader(r io.Reader, api string, limit int64) *maxBytesReader {
+	return &maxBytesReader{r: r, remaining: limit, err: &ResponseTooLargeError{API: api, Limit: limit}}
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if m.remaining <= 0 {
+		// Read one more byte to tell a body of exactly the limit from a
+		// longer one.
+		var probe [1]byte
+		n, err := m.r.Read(probe[:])
+		if n > 0 {
+			return 0, m.err
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > m.remaining {
+		p = p[:m.remaining]
+	}
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+
+	return n, err
+}
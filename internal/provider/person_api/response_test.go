@@ -0,0 +1,135 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	profile := `{"user_id": {"value": "ad|Mozilla-LDAP|jdoe"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(profile))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		limit   int64
+		tooLong bool
+	}{
+		{int64(len(profile)), false},
+		{int64(len(profile)) - 1, true},
+		{DefaultMaxResponseSize, false},
+	}
+
+	for _, test := range tests {
+		client := NewClient(staticToken("test"), server.URL, "", "")
+		client.SetMaxResponseSize(test.limit)
+
+		_, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com")
+
+		var tooLarge *ResponseTooLargeError
+		if got := errors.As(err, &tooLarge); got != test.tooLong {
+			t.Errorf("limit %d: error = %v, want ResponseTooLargeError %t", test.limit, err, test.tooLong)
+		} else if !test.tooLong && err != nil {
+			t.Errorf("limit %d: error = %s", test.limit, err)
+		}
+	}
+}
+
+func TestMaxResponseSizeList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users": [`))
+		for i := 0; i < 1000; i++ {
+			if i > 0 {
+				_, _ = w.Write([]byte(`,`))
+			}
+			_, _ = w.Write([]byte(`{"id": "x", "profile": {"user_id": {"value": "x"}}}`))
+		}
+		_, _ = w.Write([]byte(`], "nextPage": null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	client.SetMaxResponseSize(4096)
+
+	// A page is only handed out once it has been read whole, so none of an
+	// oversized page is.
+	visited := 0
+	var err error
+	for _, personErr := range client.People(context.Background(), ListOptions{}) {
+		if personErr != nil {
+			err = personErr
+			break
+		}
+		visited++
+	}
+
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("People() error = %v, want ResponseTooLargeError", err)
+	}
+	if visited != 0 {
+		t.Errorf("visited %d profiles of an oversized page, want none", visited)
+	}
+}
+
+func TestDecodePage(t *testing.T) {
+	tests := []struct {
+		page     string
+		profiles []string
+		nextPage string
+	}{
+		{
+			`{"users": [{"id": "a", "profile": {"user_id": {"value": "a"}}}, {"profile": {"user_id": {"value": "b"}}, "id": "b"}], "nextPage": "abc"}`,
+			[]string{`{"user_id": {"value": "a"}}`, `{"user_id": {"value": "b"}}`},
+			`"abc"`,
+		},
+		{
+			`{"nextPage": {"id": "ad|a"}, "count": 1, "users": [{"id": "a", "profile": {}}]}`,
+			[]string{`{}`},
+			`{"id": "ad|a"}`,
+		},
+		{`{"users": null, "nextPage": null}`, nil, `null`},
+		{`{}`, nil, ``},
+	}
+
+	for _, test := range tests {
+		var profiles []string
+		nextPage, err := decodePage(strings.NewReader(test.page), func(profile json.RawMessage) error {
+			profiles = append(profiles, string(profile))
+			return nil
+		})
+		if err != nil {
+			t.Errorf("decodePage(%s) error = %s", test.page, err)
+			continue
+		}
+		if !reflect.DeepEqual(profiles, test.profiles) {
+			t.Errorf("decodePage(%s) profiles = %q, want %q", test.page, profiles, test.profiles)
+		}
+		if string(nextPage) != test.nextPage {
+			t.Errorf("decodePage(%s) nextPage = %s, want %s", test.page, nextPage, test.nextPage)
+		}
+	}
+
+	for _, page := range []string{`[]`, `{"users": {}}`, `{"users": [{"profile": {}}`} {
+		if _, err := decodePage(strings.NewReader(page), func(json.RawMessage) error { return nil }); err == nil {
+			t.Errorf("decodePage(%s) succeeded", page)
+		}
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	_, err := decodePage(strings.NewReader(`{"users": [{"profile": {}}, {"profile": {}}]}`), func(json.RawMessage) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Errorf("decodePage() with a failing visit: error = %v after %d profiles", err, visited)
+	}
+}
@@ -0,0 +1,183 @@
+package person_api
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// profileSchema is one version of the CIS profile schema.
+type profileSchema struct {
+	// url is the URL profiles of this version declare in their "schema"
+	// attribute.
+	url string
+
+	// file is the vendored copy of the schema, under schemas/.
+	file string
+
+	// source is where the schema is maintained upstream.
+	source string
+
+	// revision is the upstream revision file was taken from, or empty while
+	// file is a hand transcription that still has to be replaced with the
+	// upstream copy.
+	revision string
+}
+
+// profileSchemas holds the vendored schema of each known profile version,
+// keyed by version. Profiles declaring any other schema URL are reported as
+// violating their schema.
+var profileSchemas = map[string]profileSchema{
+	"v2": {
+		url:    "https://person-api.sso.mozilla.com/schema/v2/profile",
+		file:   "schemas/profile_v2.json",
+		source: "https://github.com/mozilla-iam/cis/blob/master/python-modules/cis_profile/cis_profile/data/profile.schema",
+	},
+}
+
+// lookupProfileSchema returns the vendored schema declaring schemaURL.
+func lookupProfileSchema(schemaURL string) (profileSchema, bool) {
+	for _, schema := range profileSchemas {
+		if schema.url == schemaURL {
+			return schema, true
+		}
+	}
+
+	return profileSchema{}, false
+}
+
+var compiledSchemas sync.Map
+
+// validateProfile checks a raw profile against the vendored schema for the
+// version it declares, and returns every violation found. An empty result
+// means the profile is valid.
+func validateProfile(schemaURL string, body []byte) []string {
+	return validate(schemaURL, "", body)
+}
+
+// validateAttributes checks the given top-level attributes of a raw profile
+// against their part of the vendored schema, leaving the rest of the
+// profile unread. Attributes missing from the profile are not reported.
+func validateAttributes(schemaURL string, raw map[string]json.RawMessage, attributes []string) []string {
+	if _, ok := lookupProfileSchema(schemaURL); !ok {
+		return []string{fmt.Sprintf("unknown profile schema version %q", schemaURL)}
+	}
+
+	violations := []string{}
+	for _, attribute := range attributes {
+		if value, ok := raw[attribute]; ok {
+			violations = append(violations, validate(schemaURL, attribute, value)...)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+
+	return violations
+}
+
+// validate checks body against the schema declared by schemaURL, or against
+// the part of it for attribute when one is given.
+func validate(schemaURL string, attribute string, body []byte) []string {
+	schema, err := compileProfileSchema(schemaURL, attribute)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	var base []string
+	if attribute != "" {
+		base = []string{attribute}
+	}
+
+	violations := []string{}
+	collectViolations(validationErr, base, message.NewPrinter(language.English), &violations)
+	sort.Strings(violations)
+
+	return violations
+}
+
+// compileProfileSchema compiles the schema declared by schemaURL, or only
+// the part of it for attribute when one is given.
+func compileProfileSchema(schemaURL string, attribute string) (*jsonschema.Schema, error) {
+	location := schemaURL
+	if attribute != "" {
+		location += "#/properties/" + attribute
+	}
+
+	if cached, ok := compiledSchemas.Load(location); ok {
+		if schema, ok := cached.(*jsonschema.Schema); ok {
+			return schema, nil
+		}
+	}
+
+	profile, ok := lookupProfileSchema(schemaURL)
+	if !ok {
+		return nil, fmt.Errorf("unknown profile schema version %q", schemaURL)
+	}
+
+	data, err := schemaFiles.ReadFile(profile.file)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, err
+	}
+
+	schema, err := compiler.Compile(location)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledSchemas.Store(location, schema)
+
+	return schema, nil
+}
+
+// collectViolations flattens a validation error tree into one line per
+// failing instance location, relative to base.
+func collectViolations(err *jsonschema.ValidationError, base []string, printer *message.Printer, violations *[]string) {
+	if len(err.Causes) == 0 {
+		location := "/" + strings.Join(append(slices.Clone(base), err.InstanceLocation...), "/")
+		*violations = append(*violations, location+": "+err.ErrorKind.LocalizedString(printer))
+		return
+	}
+
+	for _, cause := range err.Causes {
+		collectViolations(cause, base, printer, violations)
+	}
+}
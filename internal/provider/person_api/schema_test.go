@@ -0,0 +1,55 @@
+package person_api
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateProfile(t *testing.T) {
+	body, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if violations := validateProfile("https://person-api.sso.mozilla.com/schema/v2/profile", body); len(violations) != 0 {
+		t.Errorf("validateProfile() = %v, want no violations", violations)
+	}
+}
+
+func TestValidateProfileViolations(t *testing.T) {
+	body := []byte(`{"schema": "https://person-api.sso.mozilla.com/schema/v2/profile", "active": {"value": "yes"}}`)
+
+	violations := validateProfile("https://person-api.sso.mozilla.com/schema/v2/profile", body)
+	if len(violations) == 0 {
+		t.Fatal("validateProfile() found no violations")
+	}
+
+	found := false
+	for _, violation := range violations {
+		if strings.HasPrefix(violation, "/active") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("validateProfile() = %v, want a violation at /active", violations)
+	}
+}
+
+func TestValidateProfileUnknownSchema(t *testing.T) {
+	violations := validateProfile("https://person-api.sso.mozilla.com/schema/v9/profile", []byte(`{}`))
+	if len(violations) != 1 || !strings.Contains(violations[0], "unknown profile schema version") {
+		t.Errorf("validateProfile() = %v, want an unknown schema version", violations)
+	}
+}
+
+func TestProfileSchemas(t *testing.T) {
+	for version, schema := range profileSchemas {
+		if !strings.Contains(schema.url, "/"+version+"/") {
+			t.Errorf("%s: schema URL %s is not for that version", version, schema.url)
+		}
+		if _, err := compileProfileSchema(schema.url, ""); err != nil {
+			t.Errorf("%s: %s", version, err)
+		}
+	}
+}
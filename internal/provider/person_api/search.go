@@ -0,0 +1,339 @@
+package person_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// minFuzzySimilarity is how close a name must be to the query, as a share of
+// the longer string that is left unchanged, to count as a fuzzy match.
+const minFuzzySimilarity = 0.75
+
+// SearchResult is a profile matching a search, with how well and why it
+// matched.
+type SearchResult struct {
+	Person *Person
+
+	// Score ranges from 0 to 1, where 1 is an exact primary email match.
+	Score float64
+
+	// MatchReason names the attribute and kind of match that produced Score,
+	// such as "email prefix" or "similar name".
+	MatchReason string
+}
+
+// SearchOptions controls how SearchPeople looks for matches.
+type SearchOptions struct {
+	// Limit is the most results to return; zero means no limit.
+	Limit int
+
+	// IncludeInactive returns inactive profiles too.
+	IncludeInactive bool
+
+	// Fuzzy looks for similar names among every profile when the Person
+	// API's attribute search finds nothing, so that misspelled names are
+	// still found. It reads the whole directory, once per client.
+	Fuzzy bool
+}
+
+// indexBuildTimeout bounds how long reading every profile into the search
+// index may take.
+const indexBuildTimeout = 10 * time.Minute
+
+// searchAttributes are the profile attributes the search index is read
+// with, besides user_id, active and primary_email.
+var searchAttributes = []string{"first_name", "last_name", "alternative_name"}
+
+// searchIndex is the local fallback for Person API deployments without
+// attribute search, and for names misspelled beyond what substring search
+// can find. It is built once per client, on first use; a build that fails
+// is retried by the next search.
+type searchIndex struct {
+	mu    sync.Mutex
+	built bool
+
+	// people holds an entry for every profile, with only the values
+	// matchPerson compares, whether the profile is active and the user ID
+	// its whole profile is read back with.
+	people []*Person
+}
+
+// SearchPeople finds the profiles whose first and last name, alternative
+// name or primary email match query, ranked by Score and then primary email.
+// Candidates come from the Person API's attribute search when it is
+// available, and from a local index of every profile otherwise.
+func (client *Client) SearchPeople(ctx context.Context, query string, options SearchOptions) ([]SearchResult, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []SearchResult{}, nil
+	}
+
+	candidates, err := client.searchCandidates(ctx, query, options.IncludeInactive)
+	indexed := false
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && searchUnsupported(statusErr.StatusCode) {
+		candidates, err = client.indexedPeople(ctx, options.IncludeInactive)
+		indexed = true
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := rankPeople(query, candidates)
+
+	// The Person API only matches substrings, so a misspelled name finds
+	// nothing there. Look for similar names in the local index instead.
+	if len(results) == 0 && options.Fuzzy {
+		candidates, err = client.indexedPeople(ctx, options.IncludeInactive)
+		if err != nil {
+			return nil, err
+		}
+		results = rankPeople(query, candidates)
+		indexed = true
+	}
+
+	if options.Limit > 0 && len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
+
+	if indexed {
+		return client.readResults(ctx, results)
+	}
+
+	return results, nil
+}
+
+// rankPeople scores candidates against query and returns the matching ones,
+// best first.
+func rankPeople(query string, candidates []*Person) []SearchResult {
+	results := []SearchResult{}
+	for _, person := range candidates {
+		score, reason := matchPerson(query, person)
+		if score > 0 {
+			results = append(results, SearchResult{Person: person, Score: score, MatchReason: reason})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Person.PrimaryEmail.Value < results[j].Person.PrimaryEmail.Value
+	})
+
+	return results
+}
+
+// searchCandidates asks the Person API for profiles with any query word in
+// their names, or with the query in their primary email.
+func (client *Client) searchCandidates(ctx context.Context, query string, includeInactive bool) ([]*Person, error) {
+	searches := []struct{ attribute, value string }{
+		{"primary_email", query},
+	}
+	for _, word := range strings.Fields(query) {
+		for _, attribute := range []string{"first_name", "last_name", "alternative_name"} {
+			searches = append(searches, struct{ attribute, value string }{attribute, word})
+		}
+	}
+
+	seen := map[string]bool{}
+	candidates := []*Person{}
+	for _, search := range searches {
+		people, err := client.ListPeopleByAttribute(ctx, search.attribute, search.value, !includeInactive)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, person := range people {
+			if !seen[person.UserID.Value] {
+				seen[person.UserID.Value] = true
+				candidates = append(candidates, person)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// indexedPeople returns the search index entry of every profile, reading
+// them on first use. The entries are shared and must not be modified.
+func (client *Client) indexedPeople(ctx context.Context, includeInactive bool) ([]*Person, error) {
+	index := &client.searchIndex
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+
+	if !index.built {
+		if err := client.buildSearchIndex(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	people := []*Person{}
+	for _, person := range index.people {
+		if includeInactive || person.Active.Value {
+			people = append(people, person)
+		}
+	}
+
+	return people, nil
+}
+
+// buildSearchIndex reads every profile into the search index, which must be
+// locked. The index is shared by every data source using the client, so it
+// is read with a context of its own rather than one that ends with the read
+// that happened to ask first.
+func (client *Client) buildSearchIndex(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), indexBuildTimeout)
+	defer cancel()
+
+	people := []*Person{}
+	for person, err := range client.People(ctx, ListOptions{Attributes: searchAttributes}) {
+		if err != nil {
+			return err
+		}
+		people = append(people, indexEntry(person))
+	}
+
+	client.searchIndex.people = people
+	client.searchIndex.built = true
+
+	return nil
+}
+
+// indexEntry keeps only what the search index needs of person.
+func indexEntry(person *Person) *Person {
+	entry := &Person{}
+	entry.UserID.Value = person.UserID.Value
+	entry.Active.Value = person.Active.Value
+	entry.PrimaryEmail.Value = person.PrimaryEmail.Value
+	entry.FirstName.Value = person.FirstName.Value
+	entry.LastName.Value = person.LastName.Value
+	entry.AlternativeName.Value = person.AlternativeName.Value
+
+	return entry
+}
+
+// readResults replaces the search index entries of results with the whole
+// profiles they stand for. Profiles removed since the index was built are
+// left out.
+func (client *Client) readResults(ctx context.Context, results []SearchResult) ([]SearchResult, error) {
+	read := make([]SearchResult, 0, len(results))
+	for _, result := range results {
+		person, err := client.GetPersonByUserID(ctx, result.Person.UserID.Value)
+		if errors.Is(err, ErrPersonNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result.Person = person
+		read = append(read, result)
+	}
+
+	return read, nil
+}
+
+// searchUnsupported reports whether a status code means the Person API
+// deployment does not offer attribute search.
+func searchUnsupported(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+
+	return false
+}
+
+// matchPerson scores how well person matches a lower-cased query.
+func matchPerson(query string, person *Person) (float64, string) {
+	email := strings.ToLower(person.PrimaryEmail.Value)
+	first := strings.ToLower(person.FirstName.Value)
+	last := strings.ToLower(person.LastName.Value)
+	fullName := strings.TrimSpace(first + " " + last)
+	alternative := strings.ToLower(person.AlternativeName.Value)
+
+	switch {
+	case email != "" && email == query:
+		return 1, "email"
+	case fullName != "" && fullName == query:
+		return 0.95, "first and last name"
+	case alternative != "" && alternative == query:
+		return 0.9, "alternative name"
+	case email != "" && strings.HasPrefix(email, query):
+		return 0.85, "email prefix"
+	case first != "" && first == query:
+		return 0.8, "first name"
+	case last != "" && last == query:
+		return 0.8, "last name"
+	case wordsPrefix(query, fullName):
+		return 0.7, "name prefix"
+	case wordsPrefix(query, alternative):
+		return 0.65, "alternative name prefix"
+	}
+
+	best := max(similarity(query, fullName), similarity(query, alternative))
+	if best >= minFuzzySimilarity {
+		return 0.5 * best, "similar name"
+	}
+
+	return 0, ""
+}
+
+// wordsPrefix reports whether every word of query is a prefix of a
+// different word of name, in order.
+func wordsPrefix(query string, name string) bool {
+	queryWords := strings.Fields(query)
+	nameWords := strings.Fields(name)
+	if len(queryWords) == 0 {
+		return false
+	}
+
+	i := 0
+	for _, word := range nameWords {
+		if i < len(queryWords) && strings.HasPrefix(word, queryWords[i]) {
+			i++
+		}
+	}
+
+	return i == len(queryWords)
+}
+
+// similarity returns 1 minus the Levenshtein distance between a and b
+// divided by the length of the longer one.
+func similarity(a string, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
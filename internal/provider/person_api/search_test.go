@@ -0,0 +1,174 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSearchPeople(t *testing.T) {
+	profile := func(id string, email string, first string, last string, active bool) Person {
+		person := Person{}
+		person.UserID.Value = id
+		person.PrimaryEmail.Value = email
+		person.FirstName.Value = first
+		person.LastName.Value = last
+		person.Active.Value = active
+		person.StaffInformation.Title.Value = "Engineer"
+		return person
+	}
+
+	everyone := []Person{
+		profile("ad|1", "jbuckley@mozilla.com", "Jon", "Buckley", true),
+		profile("ad|2", "jbuck@mozilla.com", "Jonathan", "Buck", true),
+		profile("ad|3", "ajones@mozilla.com", "Anna", "Jones", true),
+		profile("ad|4", "jonas@mozilla.com", "Jonas", "Smith", false),
+	}
+
+	// A deployment without attribute search, so that only the local index
+	// can answer.
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, person := range everyone {
+			if r.URL.Path == "/v2/user/user_id/"+person.UserID.Value {
+				_ = json.NewEncoder(w).Encode(person)
+				return
+			}
+		}
+		if r.URL.Path != "/v2/users/id/all" {
+			http.NotFound(w, r)
+			return
+		}
+		listed++
+
+		users := []map[string]interface{}{}
+		for _, person := range everyone {
+			users = append(users, map[string]interface{}{"id": person.UserID.Value, "profile": person})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"users": users, "nextPage": nil})
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	tests := []struct {
+		query           string
+		limit           int
+		includeInactive bool
+		want            []string
+	}{
+		{"jbuck@mozilla.com", 0, false, []string{"jbuck@mozilla.com: email"}},
+		{"JBUCK", 0, false, []string{"jbuck@mozilla.com: email prefix", "jbuckley@mozilla.com: email prefix"}},
+		{"jon buckley", 0, false, []string{"jbuckley@mozilla.com: first and last name"}},
+		{"jon buck", 0, false, []string{"jbuck@mozilla.com: name prefix", "jbuckley@mozilla.com: name prefix"}},
+		{"jon", 1, false, []string{"jbuckley@mozilla.com: first name"}},
+		{"jonas", 0, false, []string{}},
+		{"jonas", 0, true, []string{"jonas@mozilla.com: email prefix"}},
+		{"ana jones", 0, false, []string{"ajones@mozilla.com: similar name"}},
+	}
+
+	for _, test := range tests {
+		results, err := client.SearchPeople(context.Background(), test.query, SearchOptions{Limit: test.limit, IncludeInactive: test.includeInactive})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := []string{}
+		for _, result := range results {
+			got = append(got, result.Person.PrimaryEmail.Value+": "+result.MatchReason)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("SearchPeople(%q) = %v, want %v", test.query, got, test.want)
+		}
+	}
+
+	if listed != 1 {
+		t.Errorf("listed every profile %d times, want 1", listed)
+	}
+
+	// The index keeps only what searching needs, and results are read back
+	// whole.
+	for _, entry := range client.searchIndex.people {
+		if entry.StaffInformation.Title.Value != "" {
+			t.Errorf("search index kept the title of %s", entry.UserID.Value)
+		}
+	}
+	results, err := client.SearchPeople(context.Background(), "jbuck@mozilla.com", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Person.StaffInformation.Title.Value != "Engineer" {
+		t.Errorf("SearchPeople() = %v, want the whole profile of jbuck@mozilla.com", results)
+	}
+
+	// Results are copies, so changing one does not change later searches.
+	results, err = client.SearchPeople(context.Background(), "anna jones", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results[0].Person.FirstName.Value = ""
+
+	results, err = client.SearchPeople(context.Background(), "anna jones", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].MatchReason != "first and last name" {
+		t.Errorf("SearchPeople() after modifying a result = %v", results)
+	}
+}
+
+func TestSearchPeopleFuzzy(t *testing.T) {
+	anna := Person{}
+	anna.UserID.Value = "ad|3"
+	anna.PrimaryEmail.Value = "ajones@mozilla.com"
+	anna.FirstName.Value = "Anna"
+	anna.LastName.Value = "Jones"
+	anna.Active.Value = true
+
+	// A deployment with attribute search, which finds nothing for a
+	// misspelled name, and whose first full listing fails.
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/users/id/all/by_attribute_contains" {
+			_, _ = w.Write([]byte(`{"users": [], "nextPage": null}`))
+			return
+		}
+		if r.URL.Path == "/v2/user/user_id/"+anna.UserID.Value {
+			_ = json.NewEncoder(w).Encode(anna)
+			return
+		}
+
+		listed++
+		if listed == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		users := []map[string]interface{}{{"id": anna.UserID.Value, "profile": anna}}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"users": users, "nextPage": nil})
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	results, err := client.SearchPeople(context.Background(), "ana jones", SearchOptions{})
+	if err != nil || len(results) != 0 || listed != 0 {
+		t.Errorf("SearchPeople() without Fuzzy = %v, %v after listing every profile %d times, want no results or listing", results, err, listed)
+	}
+
+	if _, err := client.SearchPeople(context.Background(), "ana jones", SearchOptions{Fuzzy: true}); err == nil {
+		t.Error("SearchPeople() did not report the failed listing")
+	}
+
+	// The failed listing is not remembered, so the next search reads every
+	// profile again.
+	results, err = client.SearchPeople(context.Background(), "ana jones", SearchOptions{Fuzzy: true})
+	if err != nil {
+		t.Fatalf("SearchPeople() after a failed listing error = %s", err)
+	}
+	if len(results) != 1 || results[0].MatchReason != "similar name" || listed != 2 {
+		t.Errorf("SearchPeople() after a failed listing = %v after listing every profile %d times, want one similar name after 2", results, listed)
+	}
+}
@@ -0,0 +1,45 @@
+package person_api
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// ListStaffByTeam returns the active staff whose staff_information.team is
+// team, sorted by primary email, decoding only attributes if given.
+func (client *Client) ListStaffByTeam(ctx context.Context, team string, attributes ...string) ([]*Person, error) {
+	return client.listStaff(ctx, "staff_information.team", team, attributes, func(person *Person) string {
+		return person.StaffInformation.Team.Value
+	})
+}
+
+// ListStaffByCostCenter returns the active staff whose
+// staff_information.cost_center is costCenter, sorted by primary email,
+// decoding only attributes if given.
+func (client *Client) ListStaffByCostCenter(ctx context.Context, costCenter string, attributes ...string) ([]*Person, error) {
+	return client.listStaff(ctx, "staff_information.cost_center", costCenter, attributes, func(person *Person) string {
+		return person.StaffInformation.CostCenter.Value
+	})
+}
+
+func (client *Client) listStaff(ctx context.Context, attribute string, value string, attributes []string, field func(*Person) string) ([]*Person, error) {
+	candidates, err := client.ListPeopleByAttribute(ctx, attribute, value, true, withAttributes(attributes, "staff_information")...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list endpoint matches substrings, so keep exact matches only.
+	staff := make([]*Person, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.StaffInformation.Staff.Value && strings.EqualFold(field(candidate), value) {
+			staff = append(staff, candidate)
+		}
+	}
+
+	sort.Slice(staff, func(i, j int) bool {
+		return staff[i].PrimaryEmail.Value < staff[j].PrimaryEmail.Value
+	})
+
+	return staff, nil
+}
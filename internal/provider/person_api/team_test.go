@@ -0,0 +1,70 @@
+package person_api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListStaffByTeam(t *testing.T) {
+	member := func(email string, team string, staff bool) Person {
+		person := Person{}
+		person.PrimaryEmail.Value = email
+		person.Active.Value = true
+		person.StaffInformation.Staff.Value = staff
+		person.StaffInformation.Team.Value = team
+		return person
+	}
+
+	// Two pages, chained with an object token as the Person API does.
+	pages := map[string]map[string]interface{}{
+		"": {
+			"users": []map[string]interface{}{
+				{"id": "1", "profile": member("zed@mozilla.com", "IAM", true)},
+				{"id": "2", "profile": member("amy@mozilla.com", "IAM Operations", true)},
+			},
+			"nextPage": map[string]string{"id": "2"},
+		},
+		`{"id":"2"}`: {
+			"users": []map[string]interface{}{
+				{"id": "3", "profile": member("bea@mozilla.com", "iam", true)},
+				{"id": "4", "profile": member("vendor@example.com", "IAM", false)},
+			},
+			"nextPage": nil,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("staff_information.team") != "IAM" || r.URL.Query().Get("active") != "true" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		page, ok := pages[r.URL.Query().Get("nextPage")]
+		if !ok {
+			t.Errorf("unexpected nextPage %q", r.URL.Query().Get("nextPage"))
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+
+	// Staff are told apart by staff_information, which is decoded even when
+	// the caller does not ask for it.
+	for _, attributes := range [][]string{nil, {"first_name"}} {
+		staff, err := client.ListStaffByTeam(context.Background(), "IAM", attributes...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		emails := []string{}
+		for _, person := range staff {
+			emails = append(emails, person.PrimaryEmail.Value)
+		}
+		if want := []string{"bea@mozilla.com", "zed@mozilla.com"}; !reflect.DeepEqual(emails, want) {
+			t.Errorf("ListStaffByTeam(%v) = %v, want %v", attributes, emails, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package person_api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// timestampLayouts are the forms CIS timestamps take. Publishers mostly
+// write "2019-04-03T20:55:37.040Z", but older profiles carry Python
+// isoformat output without a time zone, with a space instead of the T, or
+// only a date.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// cisTimestampLayout is how CIS writes timestamps.
+const cisTimestampLayout = "2006-01-02T15:04:05.000Z"
+
+// ParseTimestamp parses a CIS timestamp, such as
+// "2019-04-03T20:55:37.040Z". Timestamps without a time zone are taken to be
+// in UTC.
+func ParseTimestamp(value string) (time.Time, error) {
+	var firstErr error
+	for _, layout := range timestampLayouts {
+		timestamp, err := time.Parse(layout, value)
+		if err == nil {
+			return timestamp, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return time.Time{}, firstErr
+}
+
+// Timestamp is a timestamp read from a profile. Text is the timestamp as the
+// API wrote it, and Time what it denotes, or the zero time when Text is
+// empty or cannot be parsed.
+type Timestamp struct {
+	Time time.Time
+	Text string
+
+	null bool
+}
+
+// NewTimestamp returns the Timestamp for t, written as CIS writes them.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t, Text: t.UTC().Format(cisTimestampLayout)}
+}
+
+// RFC3339 returns the time in UTC as an RFC 3339 timestamp, or an empty
+// string when the time is not known.
+func (timestamp Timestamp) RFC3339() string {
+	if timestamp.Time.IsZero() {
+		return ""
+	}
+
+	return timestamp.Time.UTC().Format(time.RFC3339Nano)
+}
+
+// UnmarshalJSON reads a timestamp string, or null. Text that cannot be
+// parsed is kept without failing the profile.
+func (timestamp *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*timestamp = Timestamp{null: true}
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	t, _ := ParseTimestamp(text)
+	*timestamp = Timestamp{Time: t, Text: text}
+
+	return nil
+}
+
+// MarshalJSON writes Text, so that profiles sent back to the Change API keep
+// the format they were read in. A Timestamp whose Time no longer matches its
+// Text is written as CIS writes timestamps.
+func (timestamp Timestamp) MarshalJSON() ([]byte, error) {
+	parsed, err := ParseTimestamp(timestamp.Text)
+
+	switch {
+	case timestamp.Text != "" && err == nil && parsed.Equal(timestamp.Time):
+		return json.Marshal(timestamp.Text)
+	case timestamp.Text != "" && err != nil && timestamp.Time.IsZero():
+		// Text that could not be parsed goes back as it came.
+		return json.Marshal(timestamp.Text)
+	case !timestamp.Time.IsZero():
+		return json.Marshal(timestamp.Time.UTC().Format(cisTimestampLayout))
+	case timestamp.null:
+		return []byte("null"), nil
+	}
+
+	return json.Marshal("")
+}
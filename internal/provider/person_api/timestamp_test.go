@@ -0,0 +1,87 @@
+package person_api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2019-04-03T20:55:37.040Z", time.Date(2019, time.April, 3, 20, 55, 37, 40e6, time.UTC)},
+		{"2019-04-03T22:55:37+02:00", time.Date(2019, time.April, 3, 20, 55, 37, 0, time.UTC)},
+		{"2019-04-03T20:55:37.040123", time.Date(2019, time.April, 3, 20, 55, 37, 40123e3, time.UTC)},
+		{"2019-04-03 20:55:37+00:00", time.Date(2019, time.April, 3, 20, 55, 37, 0, time.UTC)},
+		{"2019-04-03 20:55:37", time.Date(2019, time.April, 3, 20, 55, 37, 0, time.UTC)},
+		{"2019-04-03", time.Date(2019, time.April, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTimestamp(test.value)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q) error = %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("ParseTimestamp(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "03/04/2019"} {
+		if _, err := ParseTimestamp(value); err == nil {
+			t.Errorf("ParseTimestamp(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		rfc3339 string
+	}{
+		{`"2019-04-03T20:55:37.040Z"`, "2019-04-03T20:55:37.04Z"},
+		{`"2019-04-03 20:55:37"`, "2019-04-03T20:55:37Z"},
+		{`"not a time"`, ""},
+		{`""`, ""},
+		{`null`, ""},
+	}
+
+	for _, test := range tests {
+		var timestamp Timestamp
+		if err := json.Unmarshal([]byte(test.json), &timestamp); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", test.json, err)
+			continue
+		}
+		if got := timestamp.RFC3339(); got != test.rfc3339 {
+			t.Errorf("Unmarshal(%s).RFC3339() = %q, want %q", test.json, got, test.rfc3339)
+		}
+
+		// Timestamps are written back as they were read.
+		encoded, err := json.Marshal(timestamp)
+		if err != nil {
+			t.Errorf("Marshal(%s) error = %v", test.json, err)
+			continue
+		}
+		if string(encoded) != test.json {
+			t.Errorf("Marshal(%s) = %s", test.json, encoded)
+		}
+	}
+
+	// A changed time is written as CIS writes timestamps.
+	timestamp := Timestamp{Text: "2019-04-03 20:55:37"}
+	timestamp.Time = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.FixedZone("CEST", 7200))
+	encoded, err := json.Marshal(timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"2024-05-01T10:00:00.000Z"`; string(encoded) != want {
+		t.Errorf("Marshal() = %s, want %s", encoded, want)
+	}
+
+	if got, want := NewTimestamp(timestamp.Time).Text, "2024-05-01T10:00:00.000Z"; got != want {
+		t.Errorf("NewTimestamp().Text = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package person_api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the IANA time zone database, so that timezones load the same way
+	// on hosts without one, such as minimal containers and Windows.
+	_ "time/tzdata"
+)
+
+// ParseTimezone returns the location named by a profile's timezone, which
+// should be an IANA time zone name such as "Europe/Berlin".
+func ParseTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+
+	// LoadLocation also accepts "" for UTC and "Local" for the host's own
+	// time zone, neither of which says anything about the person.
+	if name == "" {
+		return nil, errors.New("timezone is empty")
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("%q is not an IANA time zone", name)
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an IANA time zone", name)
+	}
+
+	return location, nil
+}
+
+// UTCOffset returns the offset from UTC of location at t, such as "+05:30"
+// or "-08:00".
+func UTCOffset(location *time.Location, t time.Time) string {
+	return t.In(location).Format("-07:00")
+}
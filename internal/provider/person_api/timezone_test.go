@@ -0,0 +1,43 @@
+package person_api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimezone(t *testing.T) {
+	for _, name := range []string{"America/Toronto", "Europe/Berlin", " Asia/Kolkata ", "UTC"} {
+		if _, err := ParseTimezone(name); err != nil {
+			t.Errorf("ParseTimezone(%q) error = %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons", "UTC+2", "../etc/passwd"} {
+		if location, err := ParseTimezone(name); err == nil {
+			t.Errorf("ParseTimezone(%q) = %s, want error", name, location)
+		}
+	}
+}
+
+func TestUTCOffset(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"America/Toronto", time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC), "-05:00"},
+		{"America/Toronto", time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC), "-04:00"},
+		{"Asia/Kolkata", time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC), "+05:30"},
+		{"UTC", time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC), "+00:00"},
+	}
+
+	for _, test := range tests {
+		location, err := ParseTimezone(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := UTCOffset(location, test.at); got != test.want {
+			t.Errorf("UTCOffset(%s, %s) = %q, want %q", test.name, test.at, got, test.want)
+		}
+	}
+}
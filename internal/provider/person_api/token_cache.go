@@ -0,0 +1,173 @@
+package person_api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+)
+
+// tokenCacheMargin is how long before its expiry a cached token stops being
+// reused, so that it does not expire during a run.
+const tokenCacheMargin = 5 * time.Minute
+
+// FileTokenCache keeps the tokens of another token source in a file, so
+// that the separate provider processes Terraform starts for plan, apply and
+// refresh share a token rather than each requesting one from Auth0. The file
+// is only readable by its owner, and is ignored if anyone else can read it.
+type FileTokenCache struct {
+	Source TokenSource
+
+	// Dir holds the cache files. It is created if missing.
+	Dir string
+
+	// Key separates tokens for different credentials, such as the client ID,
+	// audience and scopes of a client credentials grant.
+	Key string
+}
+
+// TokenCacheKey returns a FileTokenCache key for tokens of the given client,
+// audience and scopes.
+func TokenCacheKey(clientID string, audience string, scopes []string) string {
+	return strings.Join([]string{clientID, audience, strings.Join(scopes, " ")}, "\n")
+}
+
+// cachedToken is the content of a token cache file.
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+func (c FileTokenCache) Token(ctx context.Context) (*oauth2.Token, error) {
+	path := c.path()
+
+	if token, ok := c.read(ctx, path); ok {
+		tflog.Debug(ctx, "Reusing cached access token", map[string]any{
+			"path":   path,
+			"expiry": token.Expiry,
+		})
+		return token, nil
+	}
+
+	token, err := c.Source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens without an expiry cannot be safely shared.
+	if !token.Expiry.IsZero() {
+		if err := c.write(path, token); err != nil {
+			tflog.Warn(ctx, "Unable to cache access token", map[string]any{
+				"path":  path,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return token, nil
+}
+
+// Expire removes the cache file if it still holds token, which the API has
+// rejected, so that neither this nor another provider process reuses it. A
+// newer token written by another process is kept.
+func (c FileTokenCache) Expire(ctx context.Context, token *oauth2.Token) {
+	path := c.path()
+
+	cached, ok := c.read(ctx, path)
+	if !ok || cached.AccessToken != token.AccessToken {
+		return
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		tflog.Warn(ctx, "Unable to remove rejected access token from cache", map[string]any{
+			"path":  path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// path returns the cache file for the key. The key is hashed, so that client
+// IDs do not appear in file names.
+func (c FileTokenCache) path() string {
+	sum := sha256.Sum256([]byte(c.Key))
+
+	return filepath.Join(c.Dir, "token-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+func (c FileTokenCache) read(ctx context.Context, path string) (*oauth2.Token, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			tflog.Debug(ctx, "Unable to read cached access token", map[string]any{"error": err.Error()})
+		}
+		return nil, false
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		tflog.Warn(ctx, "Ignoring cached access token readable by other users", map[string]any{"path": path})
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil || cached.AccessToken == "" {
+		return nil, false
+	}
+	if time.Until(cached.Expiry) < tokenCacheMargin {
+		return nil, false
+	}
+
+	return &oauth2.Token{
+		AccessToken: cached.AccessToken,
+		TokenType:   cached.TokenType,
+		Expiry:      cached.Expiry,
+	}, true
+}
+
+// write replaces the cache file in one step, so that other provider
+// processes never read half a token.
+func (c FileTokenCache) write(path string, token *oauth2.Token) error {
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedToken{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      token.Expiry,
+	})
+	if err != nil {
+		return err
+	}
+
+	// CreateTemp creates files with mode 0600.
+	file, err := os.CreateTemp(c.Dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	// Once renamed, the temporary file no longer exists to be removed.
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,105 @@
+package person_api
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	source := &countingTokenSource{}
+	key := TokenCacheKey("client", "api.sso.mozilla.com", []string{"display:staff"})
+
+	// Each cache stands in for a separate provider process.
+	for i := 0; i < 2; i++ {
+		cache := FileTokenCache{Source: source, Dir: dir, Key: key}
+		token, err := cache.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token" {
+			t.Errorf("Token() = %q, want token", token.AccessToken)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("two processes requested %d tokens, want 1", source.calls)
+	}
+
+	path := FileTokenCache{Dir: dir, Key: key}.path()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("cache file mode = %o, want 600", info.Mode().Perm())
+	}
+
+	// Other credentials do not share the token.
+	other := FileTokenCache{Source: source, Dir: dir, Key: TokenCacheKey("client", "api.dev.sso.allizom.org", []string{"display:staff"})}
+	if _, err := other.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls != 2 {
+		t.Errorf("requested %d tokens for another audience, want 2", source.calls)
+	}
+
+	// A file others can read is not trusted.
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := (FileTokenCache{Dir: dir, Key: key}).read(context.Background(), path); ok {
+		t.Error("read a cache file readable by other users")
+	}
+
+	// Tokens close to expiry are replaced.
+	cache := FileTokenCache{Source: source, Dir: dir, Key: key}
+	if err := cache.write(path, &oauth2.Token{AccessToken: "old", Expiry: time.Now().Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.read(context.Background(), path); ok {
+		t.Error("reused a token about to expire")
+	}
+}
+
+func TestFileTokenCacheRejected(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"users": [], "nextPage": null}`))
+	}))
+	defer server.Close()
+
+	source := &countingTokenSource{}
+	cache := FileTokenCache{Source: source, Dir: t.TempDir(), Key: TokenCacheKey("client", "api.sso.mozilla.com", nil)}
+	client := NewClient(cache, server.URL, "", "")
+
+	if _, err := client.ListPeopleByAttribute(context.Background(), "staff_information.team", "IAM", true); err == nil {
+		t.Fatal("ListPeopleByAttribute() succeeded despite 401")
+	}
+
+	// The rejected token is gone from the file as well as the client, so
+	// that other provider processes do not reuse it either.
+	if _, err := os.Stat(cache.path()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("cache file after a 401: %v, want it removed", err)
+	}
+
+	status = http.StatusOK
+	if _, err := client.ListPeopleByAttribute(context.Background(), "staff_information.team", "IAM", true); err != nil {
+		t.Fatalf("ListPeopleByAttribute() after a 401 error = %s", err)
+	}
+	if source.calls != 2 {
+		t.Errorf("requested %d tokens after a 401, want 2", source.calls)
+	}
+	if _, ok := cache.read(context.Background(), cache.path()); !ok {
+		t.Error("the replacement token was not cached")
+	}
+}
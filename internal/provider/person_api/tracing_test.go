@@ -0,0 +1,69 @@
+package person_api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user_id": {"value": "ad|Mozilla-LDAP|jdoe"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(staticToken("test"), server.URL, "", "")
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPersonByEmail(context.Background(), "jdoe@mozilla.com"); err != nil {
+			t.Fatalf("GetPersonByEmail() error = %s", err)
+		}
+	}
+
+	type span struct {
+		name       string
+		attributes map[string]any
+	}
+	want := []span{
+		{"person_api.cache", map[string]any{"cis.cache.hit": false}},
+		{"person_api.token", map[string]any{}},
+		{"HTTP GET", map[string]any{
+			"http.request.method":       "GET",
+			"url.full":                  server.URL + "/v2/user/primary_email/REDACTED",
+			"http.response.status_code": int64(http.StatusOK),
+		}},
+		{"person_api.cache", map[string]any{"cis.cache.hit": true}},
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != len(want) {
+		t.Fatalf("got %d spans, want %d: %v", len(spans), len(want), spans)
+	}
+	for i, w := range want {
+		if spans[i].Name != w.name {
+			t.Errorf("span %d = %s, want %s", i, spans[i].Name, w.name)
+			continue
+		}
+
+		attributes := map[string]any{}
+		for _, kv := range spans[i].Attributes {
+			attributes[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		for key, value := range w.attributes {
+			if attributes[key] != value {
+				t.Errorf("span %s attribute %s = %v, want %v", w.name, key, attributes[key], value)
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package person_api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpSubsystem is the tflog subsystem of Person and Change API requests.
+// Its level can be set apart from the provider's with the
+// TF_LOG_PROVIDER_CIS_HTTP environment variable.
+const httpSubsystem = "cis.http"
+
+// newTransport returns the transport of the API client, which traces and
+// logs every request.
+func newTransport(next http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{next: &loggingTransport{next: next}}
+}
+
+// loggingTransport logs each request to the cis.http subsystem once its
+// response has been read, with identifiers in the URL redacted and no
+// headers.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), httpSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CIS_HTTP"))
+	fields := map[string]any{
+		"method": req.Method,
+		"url":    redactURL(req.URL),
+		// Requests are never retried, since Change API writes are not safe
+		// to repeat. The field is kept so that log queries need not change
+		// if GETs are ever retried.
+		"retries": 0,
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		fields["duration_ms"] = time.Since(start).Milliseconds()
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, httpSubsystem, "HTTP request failed", fields)
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if requestID := resp.Header.Get("X-Request-Id"); requestID != "" {
+		fields["request_id"] = requestID
+	} else if requestID := resp.Header.Get("X-Amzn-Requestid"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+
+	resp.Body = &loggedBody{ReadCloser: resp.Body, ctx: ctx, fields: fields, start: start}
+
+	return resp, nil
+}
+
+// loggedBody logs its request when closed, so that the log includes the
+// size of the response and the time taken to read it.
+type loggedBody struct {
+	io.ReadCloser
+	ctx    context.Context
+	fields map[string]any
+	start  time.Time
+	size   int64
+	logged bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	if !b.logged {
+		b.logged = true
+		b.fields["duration_ms"] = time.Since(b.start).Milliseconds()
+		b.fields["response_size"] = b.size
+		tflog.SubsystemDebug(b.ctx, httpSubsystem, "HTTP request", b.fields)
+	}
+
+	return err
+}
+
+// personLookupKeys are the path segments of Person API lookups that are
+// followed by an identifier, such as /v2/user/primary_email/{email}.
+var personLookupKeys = map[string]bool{
+	"primary_email":    true,
+	"primary_username": true,
+	"user_id":          true,
+}
+
+// redactURL returns u without credentials, query values or the identifiers
+// of Person API lookups, which would otherwise put people's emails and
+// user IDs in the logs.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.Fragment = ""
+
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i := 1; i < len(segments); i++ {
+		if personLookupKeys[segments[i-1]] {
+			segments[i] = "REDACTED"
+		}
+	}
+	redacted.RawPath = strings.Join(segments, "/")
+	redacted.Path, _ = url.PathUnescape(redacted.RawPath)
+
+	query := u.Query()
+	for key := range query {
+		query[key] = []string{"REDACTED"}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
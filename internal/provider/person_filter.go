@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of the provider's inactive_policy setting, which controls what data
+// sources do with profiles that are no longer active.
+const (
+	// InactivePolicyError fails the read.
+	InactivePolicyError = "error"
+	// InactivePolicyWarn returns the profile with a warning.
+	InactivePolicyWarn = "warn"
+	// InactivePolicyOmit leaves the profile out of lists, and returns only
+	// the lookup keys and active flag from lookups.
+	InactivePolicyOmit = "omit"
+	// InactivePolicyAllow returns the profile as is.
+	InactivePolicyAllow = "allow"
+)
+
+var inactivePolicyValidator = stringvalidator.OneOf(InactivePolicyError, InactivePolicyWarn, InactivePolicyOmit, InactivePolicyAllow)
+
+var classificationValidator = stringvalidator.OneOf(levelStrings(person_api.Classifications)...)
+var displayValidator = stringvalidator.OneOf(levelStrings(person_api.DisplayLevels)...)
+
+var classificationValuesDescription = "Valid values, from least to most restricted, are " + levelList(person_api.Classifications) + "."
+var displayValuesDescription = "Valid values, from least to most restricted, are " + levelList(person_api.DisplayLevels) + "."
+
+// personFilter applies the provider's schema, inactive and attribute
+// policies to the profiles a data source reads, and collects a single set of
+// diagnostics for all of them, so that list data sources do not repeat the
+// same warning for every profile.
+type personFilter struct {
+	maxClassification person_api.Classification
+	maxDisplay        person_api.DinoParkDisplay
+	strictSchema      bool
+	inactivePolicy    string
+
+	invalid  []string
+	inactive []string
+	withheld map[string]bool
+	people   int
+}
+
+// newPersonFilter returns a filter for a data source, preferring the data
+// source's own max_classification and max_display over the provider's.
+func newPersonFilter(providerData *CISProviderData, maxClassification types.String, maxDisplay types.String) *personFilter {
+	filter := &personFilter{
+		maxClassification: providerData.MaxClassification,
+		maxDisplay:        providerData.MaxDisplay,
+		strictSchema:      providerData.StrictSchema,
+		inactivePolicy:    providerData.InactivePolicy,
+		withheld:          map[string]bool{},
+	}
+
+	if maxClassification.ValueString() != "" {
+		filter.maxClassification = person_api.Classification(maxClassification.ValueString())
+	}
+	if maxDisplay.ValueString() != "" {
+		filter.maxDisplay = person_api.DinoParkDisplay(maxDisplay.ValueString())
+	}
+
+	return filter
+}
+
+// Apply records the schema violations and inactivity of person, blanks out
+// its attributes above the filter's limits, and reports whether it should
+// be returned at all.
+func (filter *personFilter) Apply(person *person_api.Person) bool {
+	filter.people++
+
+	for _, violation := range person.SchemaViolations {
+		filter.invalid = append(filter.invalid, person.PrimaryEmail.Value+": "+violation)
+	}
+
+	if !person.Active.Value {
+		filter.inactive = append(filter.inactive, person.PrimaryEmail.Value)
+
+		if filter.inactivePolicy == InactivePolicyError || filter.inactivePolicy == InactivePolicyOmit {
+			return false
+		}
+	}
+
+	for _, attribute := range person.Withhold(filter.maxClassification, filter.maxDisplay) {
+		filter.withheld[attribute] = true
+	}
+
+	return true
+}
+
+// IncludesInactive reports whether lists should ask the API for inactive
+// profiles. Under the error and omit policies a list leaves them out, so
+// that an inactive profile does not fail the whole read.
+func (filter *personFilter) IncludesInactive() bool {
+	return filter.inactivePolicy == InactivePolicyWarn || filter.inactivePolicy == InactivePolicyAllow
+}
+
+// Diagnostics returns the diagnostics for every profile applied so far.
+func (filter *personFilter) Diagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(filter.invalid) > 0 {
+		summary := "Profile does not match its schema"
+		detail := "The following profiles do not match the schema they declare:\n\n" + strings.Join(filter.invalid, "\n")
+
+		if filter.strictSchema {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail+"\n\nSet strict_schema in the provider configuration to make this an error.")
+		}
+	}
+
+	if len(filter.inactive) > 0 {
+		switch filter.inactivePolicy {
+		case InactivePolicyError:
+			diags.AddError(
+				"Inactive person",
+				fmt.Sprintf("The following profiles are not active: %s. Set inactive_policy in the provider configuration to return inactive profiles.", strings.Join(filter.inactive, ", ")),
+			)
+		case InactivePolicyWarn:
+			diags.AddWarning(
+				"Inactive person",
+				fmt.Sprintf("The following profiles are not active: %s.", strings.Join(filter.inactive, ", ")),
+			)
+		}
+	}
+
+	if len(filter.withheld) > 0 {
+		withheld := make([]string, 0, len(filter.withheld))
+		for attribute := range filter.withheld {
+			withheld = append(withheld, attribute)
+		}
+		sort.Strings(withheld)
+
+		diags.AddWarning(
+			"Profile attributes withheld",
+			fmt.Sprintf("The following attributes exceed max_classification %q or max_display %q in at least one of %d profiles and were not written to state: %s",
+				filter.maxClassification, filter.maxDisplay, filter.people, strings.Join(withheld, ", ")),
+		)
+	}
+
+	return diags
+}
+
+func levelStrings[T ~string](levels []T) []string {
+	result := make([]string, len(levels))
+	for i, level := range levels {
+		result[i] = string(level)
+	}
+
+	return result
+}
+
+func levelList[T ~string](levels []T) string {
+	quoted := make([]string, len(levels))
+	for i, level := range levels {
+		quoted[i] = "`" + string(level) + "`"
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-cis/internal/provider/person_api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPersonFilterSchemaViolations(t *testing.T) {
+	person := &person_api.Person{SchemaViolations: []string{"/last_name/value: expected string, but got number"}}
+	person.Active.Value = true
+	person.PrimaryEmail.Value = "jdoe@mozilla.com"
+
+	tests := []struct {
+		strict       bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{false, 0, 1},
+		{true, 1, 0},
+	}
+
+	for _, test := range tests {
+		filter := newPersonFilter(&CISProviderData{StrictSchema: test.strict, InactivePolicy: InactivePolicyError}, types.StringNull(), types.StringNull())
+		if !filter.Apply(person) {
+			t.Errorf("strict_schema = %t: Apply() left out an active profile", test.strict)
+		}
+
+		diags := filter.Diagnostics()
+		if diags.ErrorsCount() != test.wantErrors || diags.WarningsCount() != test.wantWarnings {
+			t.Errorf("strict_schema = %t: Diagnostics() = %v, want %d errors and %d warnings", test.strict, diags, test.wantErrors, test.wantWarnings)
+		}
+	}
+}
+
+func TestPersonFilterUnknownSchema(t *testing.T) {
+	server := newTestPersonServer(t, map[string]any{
+		"schema":        "https://person-api.sso.mozilla.com/schema/v9/profile",
+		"primary_email": map[string]any{"value": "jdoe@mozilla.com"},
+	})
+
+	person, err := server.client().GetPersonByUserID(context.Background(), testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		strict       bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{false, 0, 1},
+		{true, 1, 0},
+	}
+
+	for _, test := range tests {
+		filter := newPersonFilter(&CISProviderData{StrictSchema: test.strict, InactivePolicy: InactivePolicyError}, types.StringNull(), types.StringNull())
+		filter.Apply(person)
+
+		diags := filter.Diagnostics()
+		if diags.ErrorsCount() != test.wantErrors || diags.WarningsCount() != test.wantWarnings {
+			t.Errorf("strict_schema = %t: Diagnostics() = %v, want %d errors and %d warnings", test.strict, diags, test.wantErrors, test.wantWarnings)
+		}
+		for _, d := range diags {
+			if !strings.Contains(d.Detail(), "unknown profile schema version") {
+				t.Errorf("strict_schema = %t: diagnostic %q does not name the unknown schema", test.strict, d.Detail())
+			}
+		}
+	}
+}
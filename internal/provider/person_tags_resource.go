@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PersonTagsResource{}
+
+func NewPersonTagsResource() resource.Resource {
+	return &PersonTagsResource{}
+}
+
+// PersonTagsResource manages a set of tags on a CIS profile. Only the tags
+// listed in the configuration are owned by the resource; tags published by
+// anything else are left untouched.
+type PersonTagsResource struct {
+	client *person_api.Client
+}
+
+// PersonTagsResourceModel describes the resource data model.
+type PersonTagsResourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	Tags   types.Set    `tfsdk:"tags"`
+	UserID types.String `tfsdk:"user_id"`
+}
+
+func (r *PersonTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person_tags"
+}
+
+func (r *PersonTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages tags on a CIS profile. Tags set by other publishers are left untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier, the same as `user_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags owned by this resource",
+				Required:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "People user identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PersonTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *PersonTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PersonTagsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "tags", nil, tagValues(tags))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create person tags", err)
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "created person tags")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PersonTagsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var owned []string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &owned, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	person, err := r.client.GetPersonByUserID(ctx, data.UserID.ValueString())
+	if errors.Is(err, person_api.ErrPersonNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read person tags", err)
+		return
+	}
+
+	// Only report the owned tags that are still present, so tags removed
+	// out of band show up as drift.
+	current := person.Tags.StringMap()
+	tags := make([]string, 0, len(owned))
+	for _, tag := range owned {
+		if _, ok := current[tag]; ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Tags, diags = types.SetValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PersonTagsResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags, previous []string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "tags", previous, tagValues(tags))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update person tags", err)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PersonTagsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous []string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "tags", previous, nil)
+	if errors.Is(err, person_api.ErrPersonNotFound) {
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete person tags", err)
+		return
+	}
+}
+
+// tagValues converts a tag set into the key/value form CIS stores tags in.
+func tagValues(tags []string) person_api.StringValues {
+	// CIS stores tags as keys without values.
+	values := make(person_api.StringValues, len(tags))
+	for _, tag := range tags {
+		values[tag] = nil
+	}
+
+	return values
+}
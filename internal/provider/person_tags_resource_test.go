@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"terraform-provider-cis/internal/provider/person_api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testUserID is the only profile testPersonServer knows.
+const testUserID = "ad|Mozilla-LDAP|jdoe"
+
+// testPersonServer serves one profile from the Person API and applies
+// changes to it from the Change API. Every other profile is not found.
+type testPersonServer struct {
+	*httptest.Server
+
+	// profile holds the attributes of the profile as JSON objects.
+	profile map[string]any
+
+	// changes holds the body of each change, in order.
+	changes []map[string]any
+}
+
+func newTestPersonServer(t *testing.T, profile map[string]any) *testPersonServer {
+	server := &testPersonServer{profile: profile}
+	server.profile["user_id"] = map[string]any{"value": testUserID}
+	server.profile["active"] = map[string]any{"value": true}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/user/user_id/"+testUserID:
+			_ = json.NewEncoder(w).Encode(server.profile)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/user" && r.URL.Query().Get("user_id") == testUserID:
+			var change map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+				t.Errorf("change body: %s", err)
+			}
+			server.changes = append(server.changes, change)
+			for attribute, value := range change {
+				server.profile[attribute] = value
+			}
+			_, _ = w.Write([]byte(`{"status_code": 200}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// lastChange returns the values of attribute in the last change.
+func (server *testPersonServer) lastChange(t *testing.T, attribute string) any {
+	if len(server.changes) == 0 {
+		t.Fatal("no change was sent")
+	}
+
+	attr, ok := server.changes[len(server.changes)-1][attribute].(map[string]any)
+	if !ok {
+		t.Fatalf("last change has no %s: %v", attribute, server.changes[len(server.changes)-1])
+	}
+
+	return attr["values"]
+}
+
+func (server *testPersonServer) client() *person_api.Client {
+	return person_api.NewClient(person_api.AccessToken("test"), server.URL, server.URL, "")
+}
+
+// newTestPlan returns a plan for r holding model.
+func newTestPlan(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+
+	return plan
+}
+
+// newTestState returns a state for r holding model, or no resource when
+// model is nil.
+func newTestState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+	if model == nil {
+		return state
+	}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("state: %v", diags)
+	}
+
+	return state
+}
+
+func testTags(t *testing.T, tags ...string) types.Set {
+	set, diags := types.SetValueFrom(context.Background(), types.StringType, tags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return set
+}
+
+func TestPersonTagsResource(t *testing.T) {
+	ctx := context.Background()
+	server := newTestPersonServer(t, map[string]any{
+		"tags": map[string]any{"values": map[string]any{"other": nil}},
+	})
+	r := &PersonTagsResource{client: server.client()}
+
+	// Create adds the configured tags to those of other publishers.
+	planned := PersonTagsResourceModel{Id: types.StringUnknown(), Tags: testTags(t, "a", "b"), UserID: types.StringValue(testUserID)}
+	createResp := resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, &planned)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create() diagnostics = %v", createResp.Diagnostics)
+	}
+
+	want := map[string]any{"a": nil, "b": nil, "other": nil}
+	if got := server.lastChange(t, "tags"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() sent tags %v, want %v", got, want)
+	}
+
+	var created PersonTagsResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Id.ValueString() != testUserID {
+		t.Errorf("Create() id = %s, want %s", created.Id, testUserID)
+	}
+
+	// Update removes the tags no longer configured, and only those.
+	planned = PersonTagsResourceModel{Id: created.Id, Tags: testTags(t, "b", "c"), UserID: created.UserID}
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newTestPlan(t, r, &planned), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update() diagnostics = %v", updateResp.Diagnostics)
+	}
+
+	want = map[string]any{"b": nil, "c": nil, "other": nil}
+	if got := server.lastChange(t, "tags"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() sent tags %v, want %v", got, want)
+	}
+
+	// Read reports owned tags removed out of band as drift.
+	server.profile["tags"] = map[string]any{"values": map[string]any{"b": nil, "other": nil}}
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", readResp.Diagnostics)
+	}
+
+	var read PersonTagsResourceModel
+	readResp.State.Get(ctx, &read)
+	if !read.Tags.Equal(testTags(t, "b")) {
+		t.Errorf("Read() tags = %s, want [b]", read.Tags)
+	}
+}
+
+func TestPersonTagsResourceReadNotFound(t *testing.T) {
+	server := newTestPersonServer(t, map[string]any{})
+	r := &PersonTagsResource{client: server.client()}
+
+	state := newTestState(t, r, &PersonTagsResourceModel{
+		Id:     types.StringValue("ad|Mozilla-LDAP|gone"),
+		Tags:   testTags(t, "a"),
+		UserID: types.StringValue("ad|Mozilla-LDAP|gone"),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Read() kept a resource whose profile no longer exists")
+	}
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PersonUrisResource{}
+
+func NewPersonUrisResource() resource.Resource {
+	return &PersonUrisResource{}
+}
+
+// PersonUrisResource manages URIs on a CIS profile. Only the URI keys listed
+// in the configuration are owned by the resource; URIs published by anything
+// else are left untouched.
+type PersonUrisResource struct {
+	client *person_api.Client
+}
+
+// PersonUrisResourceModel describes the resource data model.
+type PersonUrisResourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	UserID types.String `tfsdk:"user_id"`
+	Uris   types.Map    `tfsdk:"uris"`
+}
+
+func (r *PersonUrisResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person_uris"
+}
+
+func (r *PersonUrisResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages URIs on a CIS profile. URIs set by other publishers are left untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier, the same as `user_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "People user identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"uris": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "URIs owned by this resource, keyed by name",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *PersonUrisResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *PersonUrisResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PersonUrisResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var uris map[string]string
+	resp.Diagnostics.Append(data.Uris.ElementsAs(ctx, &uris, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "uris", nil, person_api.NewStringValues(uris))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create person URIs", err)
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "created person URIs")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonUrisResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PersonUrisResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var owned map[string]string
+	resp.Diagnostics.Append(data.Uris.ElementsAs(ctx, &owned, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	person, err := r.client.GetPersonByUserID(ctx, data.UserID.ValueString())
+	if errors.Is(err, person_api.ErrPersonNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read person URIs", err)
+		return
+	}
+
+	// Only report the owned URIs that are still present, with their current
+	// values, so URIs changed or removed out of band show up as drift.
+	current := person.Uris.StringMap()
+	uris := make(map[string]string, len(owned))
+	for key := range owned {
+		if value, ok := current[key]; ok {
+			uris[key] = value
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Uris, diags = types.MapValueFrom(ctx, types.StringType, uris)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonUrisResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PersonUrisResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var uris, previous map[string]string
+	resp.Diagnostics.Append(data.Uris.ElementsAs(ctx, &uris, false)...)
+	resp.Diagnostics.Append(state.Uris.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "uris", mapKeys(previous), person_api.NewStringValues(uris))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update person URIs", err)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PersonUrisResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PersonUrisResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous map[string]string
+	resp.Diagnostics.Append(data.Uris.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateOwnedValues(ctx, data.UserID.ValueString(), "uris", mapKeys(previous), nil)
+	if errors.Is(err, person_api.ErrPersonNotFound) {
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete person URIs", err)
+		return
+	}
+}
+
+// mapKeys returns the keys of a map of owned values.
+func mapKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testUris(t *testing.T, uris map[string]string) types.Map {
+	value, diags := types.MapValueFrom(context.Background(), types.StringType, uris)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return value
+}
+
+func TestPersonUrisResource(t *testing.T) {
+	ctx := context.Background()
+	server := newTestPersonServer(t, map[string]any{
+		"uris": map[string]any{"values": map[string]any{"blog": "https://blog.example.com"}},
+	})
+	r := &PersonUrisResource{client: server.client()}
+
+	// Create adds the configured URIs to those of other publishers.
+	planned := PersonUrisResourceModel{
+		Id:     types.StringUnknown(),
+		UserID: types.StringValue(testUserID),
+		Uris:   testUris(t, map[string]string{"github": "https://github.com/jdoe", "site": "https://jdoe.example.com"}),
+	}
+	createResp := resource.CreateResponse{State: newTestState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newTestPlan(t, r, &planned)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create() diagnostics = %v", createResp.Diagnostics)
+	}
+
+	want := map[string]any{"blog": "https://blog.example.com", "github": "https://github.com/jdoe", "site": "https://jdoe.example.com"}
+	if got := server.lastChange(t, "uris"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() sent URIs %v, want %v", got, want)
+	}
+
+	var created PersonUrisResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Id.ValueString() != testUserID {
+		t.Errorf("Create() id = %s, want %s", created.Id, testUserID)
+	}
+
+	// Update changes and removes owned URIs, and leaves the others alone.
+	planned = PersonUrisResourceModel{
+		Id:     created.Id,
+		UserID: created.UserID,
+		Uris:   testUris(t, map[string]string{"github": "https://github.com/janedoe"}),
+	}
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newTestPlan(t, r, &planned), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update() diagnostics = %v", updateResp.Diagnostics)
+	}
+
+	want = map[string]any{"blog": "https://blog.example.com", "github": "https://github.com/janedoe"}
+	if got := server.lastChange(t, "uris"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() sent URIs %v, want %v", got, want)
+	}
+
+	// Read reports owned URIs changed out of band as drift.
+	server.profile["uris"] = map[string]any{"values": map[string]any{"blog": "https://blog.example.com", "github": "https://github.com/someone"}}
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", readResp.Diagnostics)
+	}
+
+	var read PersonUrisResourceModel
+	readResp.State.Get(ctx, &read)
+	if wantUris := testUris(t, map[string]string{"github": "https://github.com/someone"}); !read.Uris.Equal(wantUris) {
+		t.Errorf("Read() URIs = %s, want %s", read.Uris, wantUris)
+	}
+}
+
+func TestPersonUrisResourceReadNotFound(t *testing.T) {
+	server := newTestPersonServer(t, map[string]any{})
+	r := &PersonUrisResource{client: server.client()}
+
+	state := newTestState(t, r, &PersonUrisResourceModel{
+		Id:     types.StringValue("ad|Mozilla-LDAP|gone"),
+		UserID: types.StringValue("ad|Mozilla-LDAP|gone"),
+		Uris:   testUris(t, map[string]string{"github": "https://github.com/jdoe"}),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Read() kept a resource whose profile no longer exists")
+	}
+}
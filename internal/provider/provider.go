@@ -5,16 +5,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"terraform-provider-cis/internal/provider/person_api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,6 +29,7 @@ import (
 // Ensure CISProvider satisfies various provider interfaces.
 var _ provider.Provider = &CISProvider{}
 var _ provider.ProviderWithFunctions = &CISProvider{}
+var _ provider.ProviderWithConfigValidators = &CISProvider{}
 
 // CISProvider defines the provider implementation.
 type CISProvider struct {
@@ -33,10 +41,43 @@ type CISProvider struct {
 
 // CISProviderModel describes the provider data model.
 type CISProviderModel struct {
+	Environment       types.String `tfsdk:"environment"`
+	AccessToken       types.String `tfsdk:"access_token"`
 	Auth0Endpoint     types.String `tfsdk:"auth0_endpoint"`
+	Auth0Audience     types.String `tfsdk:"auth0_audience"`
 	Auth0ClientID     types.String `tfsdk:"auth0_client_id"`
 	Auth0ClientSecret types.String `tfsdk:"auth0_client_secret"`
+	Auth0PrivateKey   types.String `tfsdk:"auth0_private_key"`
+	Auth0PrivateKeyID types.String `tfsdk:"auth0_private_key_id"`
+	Auth0TokenCommand types.List   `tfsdk:"auth0_token_command"`
 	PersonEndpoint    types.String `tfsdk:"person_endpoint"`
+	ChangeEndpoint    types.String `tfsdk:"change_endpoint"`
+	MaxClassification types.String `tfsdk:"max_classification"`
+	MaxDisplay        types.String `tfsdk:"max_display"`
+	MaxResponseSize   types.Int64  `tfsdk:"max_response_size"`
+	StrictSchema      types.Bool   `tfsdk:"strict_schema"`
+	InactivePolicy    types.String `tfsdk:"inactive_policy"`
+	TokenCacheDir     types.String `tfsdk:"token_cache_dir"`
+}
+
+// CISProviderData is passed to data sources and resources once the provider
+// is configured.
+type CISProviderData struct {
+	Client *person_api.Client
+
+	// MaxClassification and MaxDisplay are the default limits on which
+	// profile attributes data sources may write to state. Empty means no
+	// limit.
+	MaxClassification person_api.Classification
+	MaxDisplay        person_api.DinoParkDisplay
+
+	// StrictSchema turns profiles that fail schema validation into errors
+	// rather than warnings.
+	StrictSchema bool
+
+	// InactivePolicy is what data sources do with inactive profiles, one of
+	// the InactivePolicy constants.
+	InactivePolicy string
 }
 
 func (p *CISProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,9 +88,20 @@ func (p *CISProvider) Metadata(ctx context.Context, req provider.MetadataRequest
 func (p *CISProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Description:         "CIS environment whose Auth0 token URL, audience, Person API and Change API are used unless overridden: prod, dev or test. May also be set with the CIS_ENVIRONMENT environment variable. Defaults to prod.",
+				MarkdownDescription: "CIS environment whose Auth0 token URL, audience, Person API and Change API are used unless overridden: `prod`, `dev` or `test`. May also be set with the `CIS_ENVIRONMENT` environment variable. Defaults to `prod`.",
+				Optional:            true,
+				Validators:          []validator.String{environmentValidator},
+			},
 			"auth0_endpoint": schema.StringAttribute{
-				Description:         "Auth0 endpoint",
-				MarkdownDescription: "Auth0 endpoint",
+				Description:         "Auth0 token endpoint, overriding the environment's",
+				MarkdownDescription: "Auth0 token endpoint, overriding the environment's",
+				Optional:            true,
+			},
+			"auth0_audience": schema.StringAttribute{
+				Description:         "Audience of the Auth0 access token, overriding the environment's",
+				MarkdownDescription: "Audience of the Auth0 access token, overriding the environment's",
 				Optional:            true,
 			},
 			"auth0_client_id": schema.StringAttribute{
@@ -64,15 +116,88 @@ func (p *CISProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"auth0_private_key": schema.StringAttribute{
+				Description:         "PEM-encoded RSA private key registered with the Auth0 client, used for private_key_jwt client authentication instead of a client secret.",
+				MarkdownDescription: "PEM-encoded RSA private key registered with the Auth0 client, used for `private_key_jwt` client authentication instead of a client secret. Read it with `file()` rather than writing it in the configuration.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"auth0_private_key_id": schema.StringAttribute{
+				Description:         "Key ID of auth0_private_key, for Auth0 clients with more than one registered key",
+				MarkdownDescription: "Key ID of `auth0_private_key`, for Auth0 clients with more than one registered key",
+				Optional:            true,
+			},
+			"auth0_token_command": schema.ListAttribute{
+				Description:         "Program and arguments of a helper that writes an access token to its standard output, as a JSON object with access_token and optionally expires_in or expires_at. It is run directly, not through a shell, whenever a new token is needed.",
+				MarkdownDescription: "Program and arguments of a helper that writes an access token to its standard output, as a JSON object with `access_token` and optionally `expires_in` (seconds) or `expires_at` (RFC 3339). It is run directly, not through a shell, whenever a new token is needed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				Description:         "Pre-issued access token for the Person and Change APIs, used instead of fetching one from Auth0. May also be set with the CIS_ACCESS_TOKEN environment variable.",
+				MarkdownDescription: "Pre-issued access token for the Person and Change APIs, used instead of fetching one from Auth0. May also be set with the `CIS_ACCESS_TOKEN` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"person_endpoint": schema.StringAttribute{
-				Description:         "CIS person endpoint",
-				MarkdownDescription: "CIS person endpoint",
+				Description:         "CIS person endpoint, overriding the environment's",
+				MarkdownDescription: "CIS person endpoint, overriding the environment's",
+				Optional:            true,
+			},
+			"change_endpoint": schema.StringAttribute{
+				Description:         "CIS change endpoint, overriding the environment's",
+				MarkdownDescription: "CIS change endpoint, overriding the environment's",
+				Optional:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				Description:         "Most restricted classification of profile attributes that data sources may return. Attributes above it are withheld from state.",
+				MarkdownDescription: "Most restricted classification of profile attributes that data sources may return. Attributes above it are withheld from state. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				Description:         "Most restricted DinoPark display level of profile attributes that data sources may return. Attributes above it are withheld from state.",
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes that data sources may return. Attributes above it are withheld from state. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"inactive_policy": schema.StringAttribute{
+				Description:         "What data sources do with inactive profiles: error fails the read, warn returns them with a warning, omit leaves them out of lists and returns only the lookup keys and active flag from lookups, and allow returns them as is. Defaults to omit, so that people who have left are not handed access.",
+				MarkdownDescription: "What data sources do with inactive profiles: `error` fails the read, `warn` returns them with a warning, `omit` leaves them out of lists and returns only the lookup keys and `active` flag from lookups, and `allow` returns them as is. Defaults to `omit`, so that people who have left are not handed access.",
+				Optional:            true,
+				Validators:          []validator.String{inactivePolicyValidator},
+			},
+			"token_cache_dir": schema.StringAttribute{
+				Description:         "Directory in which to keep Auth0 access tokens between provider runs, so that each plan, apply and refresh does not request a new token. Tokens are stored per client ID, audience and scopes, in files only readable by their owner. May also be set with the CIS_TOKEN_CACHE_DIR environment variable. Tokens are not cached by default.",
+				MarkdownDescription: "Directory in which to keep Auth0 access tokens between provider runs, so that each plan, apply and refresh does not request a new token. Tokens are stored per client ID, audience and scopes, in files only readable by their owner. May also be set with the `CIS_TOKEN_CACHE_DIR` environment variable. Tokens are not cached by default.",
+				Optional:            true,
+			},
+			"max_response_size": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Largest Person API response body to read, in bytes. Reads of larger responses fail rather than hold them in memory. Defaults to %d (16 MiB).", person_api.DefaultMaxResponseSize),
+				MarkdownDescription: fmt.Sprintf("Largest Person API response body to read, in bytes. Reads of larger responses fail rather than hold them in memory. Defaults to `%d` (16 MiB).", person_api.DefaultMaxResponseSize),
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1024)},
+			},
+			"strict_schema": schema.BoolAttribute{
+				Description:         "Fail when a profile declares an unknown schema version or does not match its schema, instead of warning.",
+				MarkdownDescription: "Fail when a profile declares an unknown schema version or does not match its schema, instead of warning.",
 				Optional:            true,
 			},
 		},
 	}
 }
 
+func (p *CISProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("access_token"),
+			path.MatchRoot("auth0_client_secret"),
+			path.MatchRoot("auth0_private_key"),
+			path.MatchRoot("auth0_token_command"),
+		),
+	}
+}
+
 func (p *CISProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring CIS client")
 
@@ -84,99 +209,117 @@ func (p *CISProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	auth0_endpoint := os.Getenv("AUTH0_ENDPOINT")
-	auth0_client_id := os.Getenv("AUTH0_CLIENT_ID")
-	auth0_client_secret := os.Getenv("AUTH0_CLIENT_SECRET")
-	person_endpoint := os.Getenv("PERSON_ENDPOINT")
+	unknown := unknownAttributes(data)
 
-	if data.Auth0Endpoint.ValueString() != "" {
-		auth0_endpoint = data.Auth0Endpoint.ValueString()
-	}
-	if data.Auth0ClientID.ValueString() != "" {
-		auth0_client_id = data.Auth0ClientID.ValueString()
-	}
-	if data.Auth0ClientSecret.ValueString() != "" {
-		auth0_client_secret = data.Auth0ClientSecret.ValueString()
-	}
-	if data.PersonEndpoint.ValueString() != "" {
-		person_endpoint = data.PersonEndpoint.ValueString()
-	}
-
-	if auth0_endpoint == "" {
-		auth0_endpoint = "https://auth.mozilla.auth0.com/oauth/token"
-	}
-	if person_endpoint == "" {
-		person_endpoint = "https://person.api.sso.mozilla.com"
+	// Endpoints are checked once they are all known.
+	endpoints, diags := resolveEndpoints(data, os.Getenv)
+	if len(unknown) == 0 {
+		resp.Diagnostics.Append(diags...)
 	}
 
+	// The client credentials are left out, since they are secret.
 	tflog.Info(ctx, "Configured CIS client", map[string]any{
-		"auth0_endpoint":      auth0_endpoint,
-		"auth0_client_id":     auth0_client_id,
-		"auth0_client_secret": auth0_client_secret,
-		"person_endpoint":     person_endpoint,
-		"HasError()":          strconv.FormatBool(resp.Diagnostics.HasError()),
+		"auth0_endpoint":  endpoints.Auth0Endpoint,
+		"auth0_audience":  endpoints.Auth0Audience,
+		"person_endpoint": endpoints.PersonEndpoint,
+		"change_endpoint": endpoints.ChangeEndpoint,
+		"HasError()":      strconv.FormatBool(resp.Diagnostics.HasError()),
 	})
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
-
-	if auth0_client_id == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("auth0_client_id"),
-			"Missing Auth0 client ID",
-			"Client ID not found in AUTH0_CLIENT_ID environment variable or provider configuration block auth0_client_id attribute.",
-		)
-	}
-	if auth0_client_secret == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("auth0_client_secret"),
-			"Missing Auth0 client secret",
-			"Client ID not found in AUTH0_CLIENT_SECRET environment variable or provider configuration block auth0_client_secret attribute.",
-		)
+	// Authentication waits for the first API call, so that plans which read
+	// nothing from CIS need neither network access nor credentials. Problems
+	// with the credentials are reported by that first call.
+	var tokenSource person_api.TokenSource
+	if len(unknown) > 0 {
+		tokenSource = unavailableTokenSource{
+			err: fmt.Errorf("provider configuration attributes %s are not known until apply", strings.Join(unknown, ", ")),
+		}
+	} else {
+		tokenSource, diags = newTokenSource(ctx, data, os.Getenv, endpoints)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Info(ctx, "Configuring OAuth2 client")
-
-	client := person_api.NewClient(auth0_client_id, auth0_client_secret, "api.sso.mozilla.com", auth0_endpoint, []string{
-		// "classification:public",
-		"classification:workgroup",
-		// "display:none",
-		// "display:public",
-		// "display:authenticated",
-		// "display:vouched",
-		"display:staff",
-	}, person_endpoint)
-
-	err := client.GetAccessToken(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initialize OAuth2 Client",
-			err.Error(),
-		)
-		return
+	userAgent := fmt.Sprintf("terraform-provider-cis/%s Terraform/%s", p.version, req.TerraformVersion)
+
+	client := person_api.NewClient(tokenSource, endpoints.PersonEndpoint, endpoints.ChangeEndpoint, userAgent)
+	if !data.MaxResponseSize.IsNull() && !data.MaxResponseSize.IsUnknown() {
+		client.SetMaxResponseSize(data.MaxResponseSize.ValueInt64())
+	}
+
+	providerData := &CISProviderData{
+		Client:            client,
+		MaxClassification: person_api.Classification(data.MaxClassification.ValueString()),
+		MaxDisplay:        person_api.DinoParkDisplay(data.MaxDisplay.ValueString()),
+		StrictSchema:      data.StrictSchema.ValueBool(),
+		InactivePolicy:    InactivePolicyOmit,
+	}
+	if data.InactivePolicy.ValueString() != "" {
+		providerData.InactivePolicy = data.InactivePolicy.ValueString()
 	}
 
-	// Example client configuration for data sources and resources
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+}
+
+// unknownAttributes returns the provider attributes whose values are not
+// known yet, as happens during plan when they refer to other resources.
+func unknownAttributes(data CISProviderModel) []string {
+	unknown := []string{}
+	for name, value := range map[string]attr.Value{
+		"access_token":         data.AccessToken,
+		"auth0_audience":       data.Auth0Audience,
+		"auth0_client_id":      data.Auth0ClientID,
+		"auth0_client_secret":  data.Auth0ClientSecret,
+		"auth0_private_key":    data.Auth0PrivateKey,
+		"auth0_private_key_id": data.Auth0PrivateKeyID,
+		"auth0_token_command":  data.Auth0TokenCommand,
+		"auth0_endpoint":       data.Auth0Endpoint,
+		"change_endpoint":      data.ChangeEndpoint,
+		"environment":          data.Environment,
+		"inactive_policy":      data.InactivePolicy,
+		"max_classification":   data.MaxClassification,
+		"max_display":          data.MaxDisplay,
+		"max_response_size":    data.MaxResponseSize,
+		"person_endpoint":      data.PersonEndpoint,
+		"strict_schema":        data.StrictSchema,
+		"token_cache_dir":      data.TokenCacheDir,
+	} {
+		if value.IsUnknown() {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
 }
 
 func (p *CISProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		NewPersonTagsResource,
+		NewPersonUrisResource,
+	}
 }
 
 func (p *CISProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewGroupDataSource,
+		NewOrgChartDataSource,
+		NewPeopleByTimezoneDataSource,
+		NewPeopleChangesDataSource,
 		NewPeopleDataSource,
+		NewPeopleSearchDataSource,
+		NewTeamDataSource,
 	}
 }
 
 func (p *CISProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewMembershipActiveFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -4,9 +4,13 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -23,3 +27,43 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProviderConfigureInactivePolicy(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		inactivePolicy types.String
+		want           string
+	}{
+		// Inactive profiles are left out unless asked for, so that people
+		// who have left are not handed access.
+		{types.StringNull(), InactivePolicyOmit},
+		{types.StringValue(InactivePolicyWarn), InactivePolicyWarn},
+	}
+
+	for _, test := range tests {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := state.Set(ctx, &CISProviderModel{
+			AccessToken:       types.StringValue("token"),
+			Auth0TokenCommand: types.ListNull(types.StringType),
+			InactivePolicy:    test.inactivePolicy,
+		})
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Configure() diagnostics = %v", resp.Diagnostics)
+		}
+
+		if got := resp.DataSourceData.(*CISProviderData).InactivePolicy; got != test.want {
+			t.Errorf("inactive_policy = %s: configured %q, want %q", test.inactivePolicy, got, test.want)
+		}
+	}
+}
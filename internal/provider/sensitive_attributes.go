@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sensitiveAttributes collects the values of profile attributes whose
+// classification keeps them out of plain state and plan output. They are
+// returned through the sensitive_attributes map, keyed by attribute name,
+// and their own attribute is left null.
+type sensitiveAttributes map[string]string
+
+// String returns value as a Terraform string, or null once value has been
+// moved into the sensitive map.
+func (s sensitiveAttributes) String(name string, metadata person_api.Metadata, value string) types.String {
+	if metadata.Classification.Sensitive() {
+		s[name] = value
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// Bool returns value as a Terraform bool, or null once value has been moved
+// into the sensitive map.
+func (s sensitiveAttributes) Bool(name string, metadata person_api.Metadata, value bool) types.Bool {
+	if metadata.Classification.Sensitive() {
+		s[name] = strconv.FormatBool(value)
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(value)
+}
+
+// Timestamp returns value as an RFC 3339 Terraform string, or null when the
+// time is not known or has been moved into the sensitive map.
+func (s sensitiveAttributes) Timestamp(name string, metadata person_api.Metadata, value person_api.Timestamp) types.String {
+	if value.Time.IsZero() {
+		return types.StringNull()
+	}
+
+	return s.String(name, metadata, value.RFC3339())
+}
+
+// StringSet returns values as a Terraform set of strings, or a null set once
+// values have been moved into the sensitive map as a JSON array.
+func (s sensitiveAttributes) StringSet(ctx context.Context, name string, metadata person_api.Metadata, values []string) (types.Set, diag.Diagnostics) {
+	if metadata.Classification.Sensitive() {
+		return types.SetNull(types.StringType), s.encode(name, values)
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// StringMap returns values as a Terraform map of strings, or a null map once
+// values have been moved into the sensitive map as a JSON object.
+func (s sensitiveAttributes) StringMap(ctx context.Context, name string, metadata person_api.Metadata, values map[string]string) (types.Map, diag.Diagnostics) {
+	if metadata.Classification.Sensitive() {
+		return types.MapNull(types.StringType), s.encode(name, values)
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, values)
+}
+
+// ObjectList returns values as a Terraform list of objects, or a null list
+// once values have been moved into the sensitive map as a JSON array.
+func (s sensitiveAttributes) ObjectList(ctx context.Context, name string, metadata person_api.Metadata, attrTypes map[string]attr.Type, values any) (types.List, diag.Diagnostics) {
+	elemType := types.ObjectType{AttrTypes: attrTypes}
+	if metadata.Classification.Sensitive() {
+		return types.ListNull(elemType), s.encode(name, values)
+	}
+
+	return types.ListValueFrom(ctx, elemType, values)
+}
+
+func (s sensitiveAttributes) encode(name string, value any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		diags.AddError("Unable to encode sensitive attribute", err.Error())
+	}
+	s[name] = string(encoded)
+
+	return diags
+}
+
+// Map returns the sensitive map as a Terraform value.
+func (s sensitiveAttributes) Map(ctx context.Context) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, types.StringType, map[string]string(s))
+}
+
+// nonSensitiveString returns value as a Terraform string, or null when its
+// classification keeps it out of plain state. It is used in nested lists,
+// where there is no sensitive map to move the value into.
+func nonSensitiveString(metadata person_api.Metadata, value string) types.String {
+	if metadata.Classification.Sensitive() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// nonSensitiveTimestamp returns value as an RFC 3339 Terraform string, or
+// null when the time is not known or its classification keeps it out of
+// plain state.
+func nonSensitiveTimestamp(metadata person_api.Metadata, value person_api.Timestamp) types.String {
+	if value.Time.IsZero() {
+		return types.StringNull()
+	}
+
+	return nonSensitiveString(metadata, value.RFC3339())
+}
+
+// nonSensitiveBool returns value as a Terraform bool, or null when its
+// classification keeps it out of plain state.
+func nonSensitiveBool(metadata person_api.Metadata, value bool) types.Bool {
+	if metadata.Classification.Sensitive() {
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(value)
+}
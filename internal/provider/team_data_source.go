@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &TeamDataSource{}
+
+func NewTeamDataSource() datasource.DataSource {
+	return &TeamDataSource{}
+}
+
+// TeamDataSource defines the data source implementation.
+type TeamDataSource struct {
+	providerData *CISProviderData
+}
+
+// TeamDataSourceModel describes the data source data model.
+type TeamDataSourceModel struct {
+	Cost_Center        types.String `tfsdk:"cost_center"`
+	Created_After      types.String `tfsdk:"created_after"`
+	Id                 types.String `tfsdk:"id"`
+	Max_Classification types.String `tfsdk:"max_classification"`
+	Max_Display        types.String `tfsdk:"max_display"`
+	Members            types.List   `tfsdk:"members"`
+	Modified_After     types.String `tfsdk:"modified_after"`
+	Team               types.String `tfsdk:"team"`
+}
+
+// TeamMemberModel describes a member of a team roster.
+type TeamMemberModel struct {
+	Cost_Center     types.String `tfsdk:"cost_center"`
+	Created         types.String `tfsdk:"created"`
+	Email           types.String `tfsdk:"email"`
+	First_Name      types.String `tfsdk:"first_name"`
+	Last_Modified   types.String `tfsdk:"last_modified"`
+	Last_Name       types.String `tfsdk:"last_name"`
+	Manager_Email   types.String `tfsdk:"manager_email"`
+	Office_Location types.String `tfsdk:"office_location"`
+	Team            types.String `tfsdk:"team"`
+	Title           types.String `tfsdk:"title"`
+	User_Id         types.String `tfsdk:"user_id"`
+}
+
+var teamMemberAttrTypes = map[string]attr.Type{
+	"cost_center":     types.StringType,
+	"created":         types.StringType,
+	"email":           types.StringType,
+	"first_name":      types.StringType,
+	"last_modified":   types.StringType,
+	"last_name":       types.StringType,
+	"manager_email":   types.StringType,
+	"office_location": types.StringType,
+	"team":            types.StringType,
+	"title":           types.StringType,
+	"user_id":         types.StringType,
+}
+
+func (d *TeamDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (d *TeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Team data source. Returns every active staff member with a given team or cost center.",
+
+		Attributes: map[string]schema.Attribute{
+			"cost_center": schema.StringAttribute{
+				MarkdownDescription: "Cost center to list, as found in `staff_information.cost_center`",
+				Optional:            true,
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: createdAfterDescription,
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the roster, `team:` or `cost_center:` followed by its name",
+				Computed:            true,
+			},
+			"max_classification": schema.StringAttribute{
+				MarkdownDescription: "Most restricted classification of profile attributes to return, overriding the provider setting. " + classificationValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{classificationValidator},
+			},
+			"max_display": schema.StringAttribute{
+				MarkdownDescription: "Most restricted DinoPark display level of profile attributes to return, overriding the provider setting. " + displayValuesDescription,
+				Optional:            true,
+				Validators:          []validator.String{displayValidator},
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Active staff in the team or cost center, ordered by `email`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cost_center": schema.StringAttribute{
+							MarkdownDescription: "Cost center",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: createdDescription,
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Primary email address",
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "First name",
+							Computed:            true,
+						},
+						"last_modified": schema.StringAttribute{
+							MarkdownDescription: lastModifiedDescription,
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "Last name",
+							Computed:            true,
+						},
+						"manager_email": schema.StringAttribute{
+							MarkdownDescription: "Work email address of the member's manager, from HRIS",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"office_location": schema.StringAttribute{
+							MarkdownDescription: "Office location",
+							Computed:            true,
+						},
+						"team": schema.StringAttribute{
+							MarkdownDescription: "Team",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "Job title",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "People user identifier",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"modified_after": schema.StringAttribute{
+				MarkdownDescription: modifiedAfterDescription,
+				Optional:            true,
+			},
+			"team": schema.StringAttribute{
+				MarkdownDescription: "Team to list, as found in `staff_information.team`",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (d *TeamDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("cost_center"),
+			path.MatchRoot("team"),
+		),
+	}
+}
+
+func (d *TeamDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*CISProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CISProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "cis_team.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data TeamDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeFilter := newTimeFilter(data.Created_After, data.Modified_After, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var people []*person_api.Person
+	var err error
+
+	attributes := []string{"access_information", "created", "first_name", "last_modified", "last_name", "staff_information"}
+
+	if data.Team.ValueString() != "" {
+		data.Id = types.StringValue("team:" + data.Team.ValueString())
+		people, err = d.providerData.Client.ListStaffByTeam(ctx, data.Team.ValueString(), attributes...)
+	} else {
+		data.Id = types.StringValue("cost_center:" + data.Cost_Center.ValueString())
+		people, err = d.providerData.Client.ListStaffByCostCenter(ctx, data.Cost_Center.ValueString(), attributes...)
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list team", err)
+		return
+	}
+
+	filter := newPersonFilter(d.providerData, data.Max_Classification, data.Max_Display)
+
+	members := []TeamMemberModel{}
+	for _, person := range people {
+		if !filter.Apply(person) || !timeFilter.Apply(person) {
+			continue
+		}
+
+		members = append(members, TeamMemberModel{
+			Cost_Center:     types.StringValue(person.StaffInformation.CostCenter.Value),
+			Created:         nonSensitiveTimestamp(person.Created.Metadata, person.Created.Value),
+			Email:           nonSensitiveString(person.PrimaryEmail.Metadata, person.PrimaryEmail.Value),
+			First_Name:      nonSensitiveString(person.FirstName.Metadata, person.FirstName.Value),
+			Last_Modified:   nonSensitiveTimestamp(person.LastModified.Metadata, person.LastModified.Value),
+			Last_Name:       nonSensitiveString(person.LastName.Metadata, person.LastName.Value),
+			Manager_Email:   types.StringValue(person.AccessInformation.Hris.Value(person_api.HrisManagersPrimaryWorkEmail)),
+			Office_Location: nonSensitiveString(person.StaffInformation.OfficeLocation.Metadata, person.StaffInformation.OfficeLocation.Value),
+			Team:            nonSensitiveString(person.StaffInformation.Team.Metadata, person.StaffInformation.Team.Value),
+			Title:           nonSensitiveString(person.StaffInformation.Title.Metadata, person.StaffInformation.Title.Value),
+			User_Id:         nonSensitiveString(person.UserID.Metadata, person.UserID.Value),
+		})
+	}
+
+	resp.Diagnostics.Append(filter.Diagnostics()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Members, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: teamMemberAttrTypes}, members)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, "Read team from API", map[string]any{
+		"members": len(members),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
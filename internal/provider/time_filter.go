@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"terraform-provider-cis/internal/provider/person_api"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	createdDescription      = "When the profile was created, as an RFC 3339 timestamp"
+	lastModifiedDescription = "When the profile was last modified, as an RFC 3339 timestamp"
+
+	createdAfterDescription  = "Only return people whose profile was created after this RFC 3339 timestamp, such as `2024-05-01T00:00:00Z`. Profiles whose `created` is unreadable or withheld are left out."
+	modifiedAfterDescription = "Only return people whose profile was last modified after this RFC 3339 timestamp, such as `2024-05-01T00:00:00Z`. Profiles whose `last_modified` is unreadable or withheld are left out."
+)
+
+// timeFilter keeps the profiles of a list data source that were created or
+// last modified after the times given in its created_after and
+// modified_after arguments.
+type timeFilter struct {
+	createdAfter  time.Time
+	modifiedAfter time.Time
+}
+
+// newTimeFilter parses the created_after and modified_after arguments,
+// adding an attribute error to diags for each that is not an RFC 3339
+// timestamp. Null arguments do not filter.
+func newTimeFilter(createdAfter types.String, modifiedAfter types.String, diags *diag.Diagnostics) timeFilter {
+	return timeFilter{
+		createdAfter:  parseTimestampArgument("created_after", createdAfter, diags),
+		modifiedAfter: parseTimestampArgument("modified_after", modifiedAfter, diags),
+	}
+}
+
+// Apply reports whether person was created and last modified after the
+// filter's times. It is meant to run after personFilter.Apply, so that
+// withheld times count as unknown.
+func (filter timeFilter) Apply(person *person_api.Person) bool {
+	return after(person.Created.Value, filter.createdAfter) && after(person.LastModified.Value, filter.modifiedAfter)
+}
+
+func after(timestamp person_api.Timestamp, limit time.Time) bool {
+	if limit.IsZero() {
+		return true
+	}
+
+	return !timestamp.Time.IsZero() && timestamp.Time.After(limit)
+}
+
+// parseTimestampArgument parses the RFC 3339 timestamp given in the
+// argument name, returning the zero time when it is null or invalid.
+func parseTimestampArgument(name string, value types.String, diags *diag.Diagnostics) time.Time {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid timestamp",
+			fmt.Sprintf("%s must be an RFC 3339 timestamp such as 2024-05-01T00:00:00Z, got error: %s", name, err.Error()),
+		)
+		return time.Time{}
+	}
+
+	return parsed
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"terraform-provider-cis/internal/provider/person_api"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeFilter(t *testing.T) {
+	person := func(created string, lastModified string) *person_api.Person {
+		p := &person_api.Person{}
+		if created != "" {
+			p.Created.Value = person_api.NewTimestamp(mustParseTime(t, created))
+		}
+		if lastModified != "" {
+			p.LastModified.Value = person_api.NewTimestamp(mustParseTime(t, lastModified))
+		}
+		return p
+	}
+
+	tests := []struct {
+		name          string
+		createdAfter  types.String
+		modifiedAfter types.String
+		person        *person_api.Person
+		want          bool
+	}{
+		{"no limits", types.StringNull(), types.StringNull(), person("", ""), true},
+		{"created after", types.StringValue("2024-01-01T00:00:00Z"), types.StringNull(), person("2024-02-01T00:00:00Z", ""), true},
+		{"created before", types.StringValue("2024-01-01T00:00:00Z"), types.StringNull(), person("2023-12-31T23:00:00Z", ""), false},
+		{"created at the limit", types.StringValue("2024-01-01T00:00:00Z"), types.StringNull(), person("2024-01-01T00:00:00Z", ""), false},
+		{"created unknown", types.StringValue("2024-01-01T00:00:00Z"), types.StringNull(), person("", "2024-02-01T00:00:00Z"), false},
+		{"offsets", types.StringNull(), types.StringValue("2024-01-01T01:00:00+02:00"), person("", "2023-12-31T23:30:00Z"), true},
+		{"both", types.StringValue("2024-01-01T00:00:00Z"), types.StringValue("2024-03-01T00:00:00Z"), person("2024-02-01T00:00:00Z", "2024-02-15T00:00:00Z"), false},
+	}
+
+	for _, test := range tests {
+		var diags diag.Diagnostics
+		filter := newTimeFilter(test.createdAfter, test.modifiedAfter, &diags)
+		if diags.HasError() {
+			t.Errorf("%s: newTimeFilter() diagnostics = %v", test.name, diags)
+			continue
+		}
+		if got := filter.Apply(test.person); got != test.want {
+			t.Errorf("%s: Apply() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTimeFilterInvalid(t *testing.T) {
+	var diags diag.Diagnostics
+	newTimeFilter(types.StringValue("2024-01-01"), types.StringValue("yesterday"), &diags)
+	if got := diags.ErrorsCount(); got != 2 {
+		t.Errorf("newTimeFilter() errors = %d, want 2: %v", got, diags)
+	}
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parsed
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer returns the tracer of data source reads. Like the person_api
+// tracer, it does nothing unless StartTracing has set up an exporter.
+func tracer() trace.Tracer {
+	return otel.Tracer("terraform-provider-cis")
+}
+
+// StartTracing sends traces to the OTLP endpoint set by
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, and
+// writes them as JSON to the file named by CIS_TRACE_FILE, so that traces of
+// CI runs can be kept and inspected offline. With none of these set, tracing
+// stays off. The returned function flushes the remaining spans and must be
+// called before the provider exits.
+func StartTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	var exporters []sdktrace.SpanExporter
+	var file *os.File
+
+	if path := os.Getenv("CIS_TRACE_FILE"); path != "" {
+		var err error
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, err
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			if file != nil {
+				_ = file.Close()
+			}
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	if len(exporters) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-cis"),
+			attribute.String("service.version", version),
+		)),
+	}
+	for _, exporter := range exporters {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(tracerProvider)
+
+	return func(ctx context.Context) error {
+		err := tracerProvider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+
+		return err
+	}, nil
+}
+
+// startSpan starts the span of a provider operation, such as
+// "cis_people.Read".
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name)
+}
+
+// endSpan marks span as failed if diags holds errors, and ends it.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	for _, d := range diags.Errors() {
+		span.SetStatus(codes.Error, d.Summary())
+		span.AddEvent(d.Summary(), trace.WithAttributes(attribute.String("detail", d.Detail())))
+	}
+	span.End()
+}
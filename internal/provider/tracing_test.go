@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+)
+
+func TestStartTracingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("CIS_TRACE_FILE", path)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	ctx := context.Background()
+	stop, err := StartTracing(ctx, "test")
+	if err != nil {
+		t.Fatalf("StartTracing() error = %s", err)
+	}
+
+	_, span := startSpan(ctx, "cis_people.Read")
+	var diags diag.Diagnostics
+	diags.AddError("Client Error", "Unable to read person")
+	endSpan(span, diags)
+
+	if err := stop(ctx); err != nil {
+		t.Fatalf("stop() error = %s", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"Name":"cis_people.Read"`, `"Description":"Client Error"`, `"Value":"terraform-provider-cis"`} {
+		if !strings.Contains(string(contents), want) {
+			t.Errorf("trace file does not contain %s:\n%s", want, contents)
+		}
+	}
+}
+
+func TestStartTracingDisabled(t *testing.T) {
+	t.Setenv("CIS_TRACE_FILE", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	previous := otel.GetTracerProvider()
+
+	stop, err := StartTracing(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("StartTracing() error = %s", err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Error("StartTracing() set a tracer provider with tracing disabled")
+	}
+	if err := stop(context.Background()); err != nil {
+		t.Errorf("stop() error = %s", err)
+	}
+}
@@ -46,7 +46,22 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	// Tracing is optional, so a provider that cannot export traces still
+	// serves. The log package writes to stderr, which Terraform keeps apart
+	// from the plugin protocol.
+	stopTracing, err := provider.StartTracing(ctx, version)
+	if err != nil {
+		log.Printf("tracing disabled: %s", err)
+		stopTracing = func(context.Context) error { return nil }
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	if stopErr := stopTracing(ctx); stopErr != nil {
+		log.Printf("flushing traces: %s", stopErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())